@@ -7,12 +7,17 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/abhishek622/interviewMin/internal/archive"
 	"github.com/abhishek622/interviewMin/internal/auth"
 	"github.com/abhishek622/interviewMin/internal/config"
 	"github.com/abhishek622/interviewMin/internal/database"
+	"github.com/abhishek622/interviewMin/internal/embedding"
+	"github.com/abhishek622/interviewMin/internal/fetcher"
 	"github.com/abhishek622/interviewMin/internal/groq"
 	"github.com/abhishek622/interviewMin/internal/handler"
+	"github.com/abhishek622/interviewMin/internal/leetcode"
 	"github.com/abhishek622/interviewMin/internal/logger"
+	"github.com/abhishek622/interviewMin/internal/notify"
 	"github.com/abhishek622/interviewMin/internal/repository"
 	"github.com/abhishek622/interviewMin/pkg"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -64,12 +69,72 @@ func main() {
 	groqClient := groq.NewClient(cfg.Groq.APIKey, cfg.Groq.Model, cfg.Groq.Timeout, log)
 	tokenMaker := auth.NewJWTMaker(cfg.JWT.Secret)
 
+	archiveStore, err := archive.New(archive.Options{Backend: cfg.Archive.Backend, Dir: cfg.Archive.Dir}, pool)
+	if err != nil {
+		sugar.Fatalw("failed to initialize archive", "error", err)
+	}
+	var fetchCache archive.Store
+	if cfg.Archive.CacheTTL > 0 {
+		fetchCache = archiveStore
+	}
+
+	fetcher.Configure(fetcher.ClientOptions{
+		UserAgent:            cfg.Fetch.UserAgent,
+		ConnectTimeout:       cfg.Fetch.ConnectTimeout,
+		ReadTimeout:          cfg.Fetch.ReadTimeout,
+		RequestTimeout:       cfg.Fetch.RequestTimeout,
+		MaxBodyBytes:         cfg.Fetch.MaxBodyBytes,
+		MaxRetries:           cfg.Fetch.MaxRetries,
+		RetryBaseDelay:       cfg.Fetch.RetryBaseDelay,
+		RetryMaxDelay:        cfg.Fetch.RetryMaxDelay,
+		MaxRedirects:         cfg.Fetch.MaxRedirects,
+		AllowPrivateNetworks: cfg.Fetch.AllowPrivateNetworks,
+		Cache:                fetchCache,
+		CacheTTL:             cfg.Archive.CacheTTL,
+	})
+
 	cryptoSvc, err := pkg.NewCrypto(cfg.Crypto.Secret)
 	if err != nil {
 		sugar.Fatalw("failed to initialize crypto service", "error", err)
 	}
 
-	hndl := handler.NewHandler(log, repo, tokenMaker, cryptoSvc, groqClient, cfg)
+	problems, err := leetcode.NewCatalog(cfg.Leetcode.ProblemsFile)
+	if err != nil {
+		sugar.Fatalw("failed to load leetcode problem catalog", "error", err)
+	}
+
+	embedder, err := embedding.New(embedding.Options{
+		Provider:   cfg.Embedding.Provider,
+		BaseURL:    cfg.Embedding.BaseURL,
+		APIKey:     cfg.Embedding.APIKey,
+		Model:      cfg.Embedding.Model,
+		Dimensions: cfg.Embedding.Dimensions,
+		Timeout:    cfg.Embedding.Timeout,
+	})
+	if err != nil {
+		sugar.Fatalw("failed to initialize embedding provider", "error", err)
+	}
+
+	notifier, err := notify.New(notify.Options{
+		Provider:   cfg.Notify.Provider,
+		WebhookURL: cfg.Notify.WebhookURL,
+		SMTPAddr:   cfg.Notify.SMTPAddr,
+		SMTPUser:   cfg.Notify.SMTPUser,
+		SMTPPass:   cfg.Notify.SMTPPass,
+		From:       cfg.Notify.From,
+		Timeout:    cfg.Notify.Timeout,
+	}, log)
+	if err != nil {
+		sugar.Fatalw("failed to initialize notifier", "error", err)
+	}
+
+	hndl := handler.NewHandler(log, repo, tokenMaker, cryptoSvc, groqClient, problems, embedder, notifier, archiveStore, cfg)
+	if err := hndl.LoadScrapeRules(ctx); err != nil {
+		sugar.Fatalw("failed to load scrape rules", "error", err)
+	}
+	if err := hndl.FailInterruptedImports(ctx); err != nil {
+		sugar.Warnw("failed to clean up interrupted imports", "error", err)
+	}
 
 	app := &application{
 		DB:         pool,
@@ -79,6 +144,22 @@ func main() {
 		Handler:    hndl,
 	}
 
+	schedulerCtx, stopSchedulers := context.WithCancel(ctx)
+	defer stopSchedulers()
+	if cfg.Resync.Enabled {
+		go hndl.RunResyncScheduler(schedulerCtx)
+		sugar.Infow("resync scheduler started", "interval", cfg.Resync.Interval.String())
+	}
+	go hndl.RunLeetcodeRefresher(schedulerCtx)
+	go hndl.DetectInterviewLanguages(schedulerCtx)
+	if cfg.Archive.CacheTTL > 0 && cfg.Archive.CacheSweepInterval > 0 {
+		go hndl.RunCacheSweeper(schedulerCtx)
+	}
+	if cfg.Watch.Enabled {
+		go hndl.RunWatchScheduler(schedulerCtx)
+		sugar.Infow("watch scheduler started", "interval", cfg.Watch.Interval.String())
+	}
+
 	// Graceful shutdown setup
 	shutdownChan := make(chan os.Signal, 1)
 	signal.Notify(shutdownChan, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
@@ -101,6 +182,7 @@ func main() {
 
 	// Graceful shutdown with timeout
 	sugar.Info("initiating graceful shutdown...")
+	stopSchedulers()
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -35,6 +35,9 @@ func (app *application) routes() http.Handler {
 			auth.POST("/tokens/renew", app.Handler.RenewAccessToken)
 		}
 
+		// called by the mail provider, authenticated with the webhook secret
+		v1.POST("/ingest/email", app.Handler.IngestEmail)
+
 		protected := v1.Group("/")
 		protected.Use(app.AuthMiddleware())
 		protected.Use(app.ReadOnlyMiddleware())
@@ -42,14 +45,37 @@ func (app *application) routes() http.Handler {
 			user := protected.Group("/user")
 			{
 				user.GET("/me", app.Handler.Me)
+				user.GET("/preferences", app.Handler.GetPreferences)
+				user.PATCH("/preferences", app.Handler.UpdatePreferences)
+				user.GET("/ingest-address", app.Handler.GetIngestAddress)
+				user.POST("/ingest-address", app.Handler.CreateIngestAddress)
 				user.POST("/logout", app.Handler.Logout)
 				user.POST("/tokens/revoke", app.Handler.RevokeSession)
 			}
 
+			protected.GET("/export", app.Handler.Export)
+
+			imports := protected.Group("/import")
+			{
+				imports.POST("", app.Handler.Import)
+				imports.GET("", app.Handler.ListImportJobs)
+				imports.GET("/:job_id", app.Handler.GetImportJob)
+			}
+
+			search := protected.Group("/search")
+			{
+				search.GET("", app.Handler.Search)
+				search.GET("/semantic", app.Handler.SemanticSearch)
+				search.POST("/semantic/reindex", app.Handler.ReindexEmbeddings)
+			}
+
 			interviews := protected.Group("/interviews")
 			{
 				interviews.POST("", app.Handler.CreateInterview)
 				interviews.POST("/ai", app.Handler.CreateInterviewWithAI)
+				interviews.POST("/ai/batch", app.Handler.BatchImportInterviews)
+				interviews.POST("/upload", app.Handler.UploadInterview)
+				interviews.GET("/ai/batch/:batch_id", app.Handler.GetImportBatch)
 				interviews.POST("/list", app.Handler.ListInterviews)
 				interviews.DELETE("", app.Handler.DeleteInterviews)
 
@@ -59,6 +85,11 @@ func (app *application) routes() http.Handler {
 
 				interviews.GET("/:interview_id", app.Handler.GetInterview)
 				interviews.PATCH("/:interview_id", app.Handler.PatchInterview)
+				interviews.PUT("/:interview_id/tags", app.Handler.SetInterviewTags)
+				interviews.POST("/:interview_id/resync", app.Handler.ResyncInterview)
+				interviews.GET("/:interview_id/snapshot", app.Handler.GetInterviewSnapshot)
+				interviews.GET("/:interview_id/attachments", app.Handler.ListAttachments)
+				interviews.GET("/:interview_id/attachments/:attachment_id", app.Handler.DownloadAttachment)
 			}
 
 			companies := protected.Group("/companies")
@@ -66,15 +97,66 @@ func (app *application) routes() http.Handler {
 				companies.GET("", app.Handler.ListCompanies)
 				companies.GET("/list/names", app.Handler.ListCompaniesNameList)
 				companies.GET("/:identifier", app.Handler.GetCompany)
+				companies.GET("/:identifier/brief", app.Handler.GetCompanyBrief)
 				companies.DELETE("/:company_id", app.Handler.DeleteCompany)
+				companies.POST("/:company_id/mock-interview", app.Handler.CreateMockInterview)
 			}
 
 			questions := protected.Group("/questions")
 			{
 				questions.POST("", app.Handler.CreateQuestion)
+				questions.GET("/bank", app.Handler.QuestionBank)
+				questions.POST("/bank/relink", app.Handler.RelinkQuestionBank)
+				questions.GET("/search", app.Handler.SearchQuestions)
 				questions.GET("/:interview_id", app.Handler.ListQuestions)
 				questions.PUT("/:q_id", app.Handler.UpdateQuestion)
 				questions.DELETE("/:q_id", app.Handler.DeleteQuestion)
+				questions.PUT("/:q_id/tags", app.Handler.SetQuestionTags)
+			}
+
+			notes := protected.Group("/notes")
+			{
+				notes.GET("/:q_id", app.Handler.GetQuestionNote)
+				notes.PUT("/:q_id", app.Handler.SaveQuestionNote)
+				notes.GET("/:q_id/revisions", app.Handler.ListNoteRevisions)
+			}
+
+			leetcode := protected.Group("/leetcode")
+			{
+				leetcode.GET("/:q_id/candidates", app.Handler.ListLeetcodeCandidates)
+				leetcode.PUT("/:q_id", app.Handler.SetQuestionLeetcode)
+				leetcode.DELETE("/:q_id", app.Handler.DeleteQuestionLeetcode)
+			}
+
+			practice := protected.Group("/practice")
+			{
+				practice.GET("/due", app.Handler.PracticeDue)
+				practice.POST("/:q_id/review", app.Handler.ReviewQuestion)
+				practice.PUT("/:q_id/status", app.Handler.UpdateQuestionProgress)
+			}
+
+			mockInterviews := protected.Group("/mock-interviews")
+			{
+				mockInterviews.GET("", app.Handler.ListMockInterviews)
+				mockInterviews.GET("/:mock_id", app.Handler.GetMockInterview)
+				mockInterviews.POST("/:mock_id/questions/:mq_id/answer", app.Handler.AnswerMockQuestion)
+				mockInterviews.DELETE("/:mock_id", app.Handler.DeleteMockInterview)
+			}
+
+			watches := protected.Group("/watches")
+			{
+				watches.GET("", app.Handler.ListWatches)
+				watches.POST("", app.Handler.CreateWatch)
+				watches.DELETE("/:watch_id", app.Handler.DeleteWatch)
+			}
+
+			tags := protected.Group("/tags")
+			{
+				tags.GET("", app.Handler.ListTags)
+				tags.POST("", app.Handler.CreateTag)
+				tags.POST("/merge", app.Handler.MergeTags)
+				tags.PATCH("/:tag_id", app.Handler.RenameTag)
+				tags.DELETE("/:tag_id", app.Handler.DeleteTag)
 			}
 		}
 
@@ -83,6 +165,12 @@ func (app *application) routes() http.Handler {
 		{
 			admin.POST("/signup", app.Handler.SignUp)
 			admin.POST("/change-password", app.Handler.ChangePassword)
+			admin.POST("/leetcode/refresh", app.Handler.RefreshLeetcodeProblems)
+			admin.GET("/scrape-rules", app.Handler.ListScrapeRules)
+			admin.POST("/scrape-rules", app.Handler.CreateScrapeRule)
+			admin.POST("/scrape-rules/test", app.Handler.TestScrapeRule)
+			admin.PUT("/scrape-rules/:rule_id", app.Handler.UpdateScrapeRule)
+			admin.DELETE("/scrape-rules/:rule_id", app.Handler.DeleteScrapeRule)
 		}
 	}
 
@@ -0,0 +1,168 @@
+// Command crawler imports LeetCode discuss interview posts of a company for one user.
+//
+//	go run ./cmd/crawler -email me@example.com -company google -since 2025-01-01
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/abhishek622/interviewMin/internal/archive"
+	"github.com/abhishek622/interviewMin/internal/auth"
+	"github.com/abhishek622/interviewMin/internal/config"
+	"github.com/abhishek622/interviewMin/internal/database"
+	"github.com/abhishek622/interviewMin/internal/embedding"
+	"github.com/abhishek622/interviewMin/internal/fetcher"
+	"github.com/abhishek622/interviewMin/internal/groq"
+	"github.com/abhishek622/interviewMin/internal/handler"
+	"github.com/abhishek622/interviewMin/internal/leetcode"
+	"github.com/abhishek622/interviewMin/internal/logger"
+	"github.com/abhishek622/interviewMin/internal/notify"
+	"github.com/abhishek622/interviewMin/internal/repository"
+	"github.com/abhishek622/interviewMin/pkg"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	_ "github.com/joho/godotenv/autoload"
+)
+
+func main() {
+	email := flag.String("email", "", "email of the user the posts are imported for")
+	company := flag.String("company", "", "leetcode discuss company tag, e.g. google")
+	since := flag.String("since", "", "only posts created on or after this date (YYYY-MM-DD)")
+	until := flag.String("until", "", "only posts created before this date (YYYY-MM-DD)")
+	maxPosts := flag.Int("max", 50, "maximum number of posts to import, 0 for no limit")
+	flag.Parse()
+
+	if *email == "" || *company == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts := fetcher.DiscussListOptions{CompanyTag: pkg.GenerateSlug(*company), MaxPosts: *maxPosts}
+	var err error
+	if *since != "" {
+		if opts.Since, err = time.Parse("2006-01-02", *since); err != nil {
+			fail("invalid -since: %v", err)
+		}
+	}
+	if *until != "" {
+		if opts.Until, err = time.Parse("2006-01-02", *until); err != nil {
+			fail("invalid -until: %v", err)
+		}
+	}
+
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fail("load config: %v", err)
+	}
+
+	log, err := logger.NewLogger(cfg.Env)
+	if err != nil {
+		fail("init logger: %v", err)
+	}
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	pool, err := database.Connect(ctx, &cfg.DB)
+	if err != nil {
+		fail("connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	repo := repository.NewRepository(pool)
+	archiveStore, err := archive.New(archive.Options{Backend: cfg.Archive.Backend, Dir: cfg.Archive.Dir}, pool)
+	if err != nil {
+		fail("init archive: %v", err)
+	}
+	var fetchCache archive.Store
+	if cfg.Archive.CacheTTL > 0 {
+		fetchCache = archiveStore
+	}
+
+	fetcher.Configure(fetcher.ClientOptions{
+		UserAgent:            cfg.Fetch.UserAgent,
+		ConnectTimeout:       cfg.Fetch.ConnectTimeout,
+		ReadTimeout:          cfg.Fetch.ReadTimeout,
+		RequestTimeout:       cfg.Fetch.RequestTimeout,
+		MaxBodyBytes:         cfg.Fetch.MaxBodyBytes,
+		MaxRetries:           cfg.Fetch.MaxRetries,
+		RetryBaseDelay:       cfg.Fetch.RetryBaseDelay,
+		RetryMaxDelay:        cfg.Fetch.RetryMaxDelay,
+		MaxRedirects:         cfg.Fetch.MaxRedirects,
+		AllowPrivateNetworks: cfg.Fetch.AllowPrivateNetworks,
+		Cache:                fetchCache,
+		CacheTTL:             cfg.Archive.CacheTTL,
+	})
+	cryptoSvc, err := pkg.NewCrypto(cfg.Crypto.Secret)
+	if err != nil {
+		fail("init crypto service: %v", err)
+	}
+	problems, err := leetcode.NewCatalog(cfg.Leetcode.ProblemsFile)
+	if err != nil {
+		fail("load leetcode problem catalog: %v", err)
+	}
+	embedder, err := embedding.New(embedding.Options{
+		Provider:   cfg.Embedding.Provider,
+		BaseURL:    cfg.Embedding.BaseURL,
+		APIKey:     cfg.Embedding.APIKey,
+		Model:      cfg.Embedding.Model,
+		Dimensions: cfg.Embedding.Dimensions,
+		Timeout:    cfg.Embedding.Timeout,
+	})
+	if err != nil {
+		fail("init embedding provider: %v", err)
+	}
+
+	notifier, err := notify.New(notify.Options{
+		Provider:   cfg.Notify.Provider,
+		WebhookURL: cfg.Notify.WebhookURL,
+		SMTPAddr:   cfg.Notify.SMTPAddr,
+		SMTPUser:   cfg.Notify.SMTPUser,
+		SMTPPass:   cfg.Notify.SMTPPass,
+		From:       cfg.Notify.From,
+		Timeout:    cfg.Notify.Timeout,
+	}, log)
+	if err != nil {
+		fail("init notifier: %v", err)
+	}
+
+	hndl := handler.NewHandler(log, repo,
+		auth.NewJWTMaker(cfg.JWT.Secret), cryptoSvc,
+		groq.NewClient(cfg.Groq.APIKey, cfg.Groq.Model, cfg.Groq.Timeout, log),
+		problems, embedder, notifier, archiveStore, cfg)
+	if err := hndl.LoadScrapeRules(ctx); err != nil {
+		fail("load scrape rules: %v", err)
+	}
+
+	user, err := repo.GetUserByEmail(ctx, *email)
+	if err != nil {
+		fail("find user %s: %v", *email, err)
+	}
+
+	batch, err := hndl.CrawlLeetcode(ctx, user.UserID, opts)
+	if err != nil {
+		fail("crawl: %v", err)
+	}
+	if batch == nil {
+		fmt.Println("no posts found")
+		return
+	}
+
+	fmt.Printf("batch %d: %d posts, %d imported, %d duplicates, %d failed\n", batch.BatchID, batch.Total,
+		batch.Counts[model.BatchItemCompleted], batch.Counts[model.BatchItemDuplicate], batch.Counts[model.BatchItemFailed])
+	for _, it := range batch.Items {
+		if it.Status == model.BatchItemFailed && it.Error != nil {
+			fmt.Printf("  failed %s: %s\n", it.URL, *it.Error)
+		}
+	}
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
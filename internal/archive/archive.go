@@ -0,0 +1,79 @@
+// Package archive stores fetched responses, both as a short lived fetch cache and as
+// permanent snapshots of imported posts, and uploaded files
+package archive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrNotFound is returned for keys that were never stored
+var ErrNotFound = errors.New("archive blob not found")
+
+// Blob is a stored response body
+type Blob struct {
+	ContentType string
+	Data        []byte
+	StoredAt    time.Time
+}
+
+// Store keeps blobs by key. Keys are made of lowercase hex and "/" only.
+type Store interface {
+	Get(ctx context.Context, key string) (*Blob, error)
+	// Put stores b under key, replacing what was stored before
+	Put(ctx context.Context, key string, b *Blob) error
+	// DeleteBefore removes the blobs whose key starts with prefix that were stored
+	// before a time and returns how many there were
+	DeleteBefore(ctx context.Context, prefix string, before time.Time) (int64, error)
+	Name() string
+}
+
+// CachePrefix starts the keys of cached fetch responses, they expire after the cache TTL
+const CachePrefix = "cache/"
+
+// Options selects and configures a store
+type Options struct {
+	Backend string // fs | postgres
+	Dir     string // fs only
+}
+
+// New returns the store selected by opts.Backend, pool is only used by the postgres backend
+func New(opts Options, pool *pgxpool.Pool) (Store, error) {
+	switch opts.Backend {
+	case "", "fs":
+		if opts.Dir == "" {
+			return nil, fmt.Errorf("fs archive needs a directory")
+		}
+		return NewFS(opts.Dir)
+	case "postgres":
+		return NewPostgres(pool), nil
+	default:
+		return nil, fmt.Errorf("unknown archive backend: %s", opts.Backend)
+	}
+}
+
+// ContentKey addresses data by its digest, so identical snapshots are stored once
+func ContentKey(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "snapshots/" + hex.EncodeToString(sum[:])
+}
+
+// AttachmentKey addresses an uploaded file by its digest
+func AttachmentKey(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "attachments/" + hex.EncodeToString(sum[:])
+}
+
+// CacheKey addresses a cached response by the request it answers
+func CacheKey(method, url string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + url + "\n"))
+	h.Write(body)
+	return CachePrefix + hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,121 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FS stores every blob in its own file below a directory. The first line of a file
+// holds the content type.
+type FS struct {
+	dir string
+}
+
+func NewFS(dir string) (*FS, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create archive directory: %w", err)
+	}
+	return &FS{dir: dir}, nil
+}
+
+func (s *FS) Name() string { return "fs" }
+
+// path shards files by the first two characters of the key's last segment
+func (s *FS) path(key string) string {
+	dir, name := filepath.Split(filepath.FromSlash(key))
+	if len(name) > 2 {
+		dir = filepath.Join(dir, name[:2])
+	}
+	return filepath.Join(s.dir, dir, name)
+}
+
+func (s *FS) Get(ctx context.Context, key string) (*Blob, error) {
+	p := s.path(key)
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("read archive blob: %w", err)
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, fmt.Errorf("stat archive blob: %w", err)
+	}
+
+	contentType, body, _ := bytes.Cut(data, []byte("\n"))
+	return &Blob{ContentType: string(contentType), Data: body, StoredAt: info.ModTime()}, nil
+}
+
+func (s *FS) Put(ctx context.Context, key string, b *Blob) error {
+	p := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return fmt.Errorf("create archive directory: %w", err)
+	}
+
+	// write to a temporary file first so readers never see a partial blob
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".blob-*")
+	if err != nil {
+		return fmt.Errorf("create archive blob: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(b.ContentType + "\n"); err == nil {
+		_, err = tmp.Write(b.Data)
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("write archive blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return fmt.Errorf("store archive blob: %w", err)
+	}
+	return nil
+}
+
+func (s *FS) DeleteBefore(ctx context.Context, prefix string, before time.Time) (int64, error) {
+	root := filepath.Join(s.dir, filepath.FromSlash(prefix))
+	var deleted int64
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// temporary files belong to writes still in progress
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if info.ModTime().Before(before) {
+			if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			deleted++
+		}
+		return nil
+	})
+	if err != nil {
+		return deleted, fmt.Errorf("delete archive blobs: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,56 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Postgres stores blobs in the archive_blobs table. Blobs are kept in a BYTEA column
+// rather than as large objects: they are capped by FETCH_MAX_BODY_BYTES and the upload
+// limit, and a row is gone with a plain DELETE, while large objects have to be unlinked
+// separately and are left behind as orphans when a row is removed without lo_unlink.
+type Postgres struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgres(db *pgxpool.Pool) *Postgres {
+	return &Postgres{db: db}
+}
+
+func (s *Postgres) Name() string { return "postgres" }
+
+func (s *Postgres) Get(ctx context.Context, key string) (*Blob, error) {
+	var b Blob
+	err := s.db.QueryRow(ctx, `SELECT content_type, data, stored_at FROM archive_blobs WHERE key = $1`, key).
+		Scan(&b.ContentType, &b.Data, &b.StoredAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get archive blob: %w", err)
+	}
+	return &b, nil
+}
+
+func (s *Postgres) Put(ctx context.Context, key string, b *Blob) error {
+	const q = `INSERT INTO archive_blobs (key, content_type, data) VALUES ($1, $2, $3)
+ON CONFLICT (key) DO UPDATE SET content_type = EXCLUDED.content_type, data = EXCLUDED.data, stored_at = NOW()`
+	if _, err := s.db.Exec(ctx, q, key, b.ContentType, b.Data); err != nil {
+		return fmt.Errorf("put archive blob: %w", err)
+	}
+	return nil
+}
+
+func (s *Postgres) DeleteBefore(ctx context.Context, prefix string, before time.Time) (int64, error) {
+	// keys are lowercase hex and "/" only, so the prefix holds no LIKE wildcards
+	tag, err := s.db.Exec(ctx, `DELETE FROM archive_blobs WHERE key LIKE $1 AND stored_at < $2`, prefix+"%", before)
+	if err != nil {
+		return 0, fmt.Errorf("delete archive blobs: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -10,14 +10,23 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Env     string `envconfig:"ENV" default:"development"`
-	Port    int    `envconfig:"PORT" default:"8080"`
-	DB      DBConfig
-	Limiter RateLimiterConfig
-	CORS    CORSConfig
-	JWT     JWTConfig
-	Crypto  CryptoConfig
-	Groq    GroqConfig
+	Env       string `envconfig:"ENV" default:"development"`
+	Port      int    `envconfig:"PORT" default:"8080"`
+	DB        DBConfig
+	Limiter   RateLimiterConfig
+	CORS      CORSConfig
+	JWT       JWTConfig
+	Crypto    CryptoConfig
+	Groq      GroqConfig
+	Leetcode  LeetcodeConfig
+	Embedding EmbeddingConfig
+	Resync    ResyncConfig
+	Watch     WatchConfig
+	Notify    NotifyConfig
+	Fetch     FetchConfig
+	Archive   ArchiveConfig
+	Scrape    ScrapeConfig
+	Ingest    IngestConfig
 }
 
 // database configuration
@@ -59,6 +68,89 @@ type GroqConfig struct {
 	Timeout time.Duration `envconfig:"GROQ_TIMEOUT" default:"30s"`
 }
 
+// LeetCode problem catalog configuration
+type LeetcodeConfig struct {
+	ProblemsFile string `envconfig:"LEETCODE_PROBLEMS_FILE"` // optional, persists refreshed problem lists
+	// how often the full problem list is downloaded, 0 turns periodic refreshes off
+	RefreshInterval time.Duration `envconfig:"LEETCODE_REFRESH_INTERVAL" default:"168h"`
+}
+
+// embedding provider configuration, the hash provider runs locally without a model
+type EmbeddingConfig struct {
+	Provider   string        `envconfig:"EMBEDDING_PROVIDER" default:"hash"` // openai | ollama | hash
+	BaseURL    string        `envconfig:"EMBEDDING_BASE_URL"`
+	APIKey     string        `envconfig:"EMBEDDING_API_KEY"`
+	Model      string        `envconfig:"EMBEDDING_MODEL"`
+	Dimensions int           `envconfig:"EMBEDDING_DIMENSIONS" default:"512"` // hash provider only
+	Timeout    time.Duration `envconfig:"EMBEDDING_TIMEOUT" default:"30s"`
+}
+
+// scheduled resync of imported interviews with their source, off unless enabled
+type ResyncConfig struct {
+	Enabled    bool          `envconfig:"RESYNC_ENABLED" default:"false"`
+	Interval   time.Duration `envconfig:"RESYNC_INTERVAL" default:"6h"`
+	MaxAge     time.Duration `envconfig:"RESYNC_MAX_AGE" default:"720h"`    // only imports newer than this are resynced
+	StaleAfter time.Duration `envconfig:"RESYNC_STALE_AFTER" default:"24h"` // refetch when the last fetch is older
+	BatchSize  int           `envconfig:"RESYNC_BATCH_SIZE" default:"20"`
+}
+
+// company watch polling, off unless enabled
+type WatchConfig struct {
+	Enabled  bool          `envconfig:"WATCH_ENABLED" default:"false"`
+	Interval time.Duration `envconfig:"WATCH_INTERVAL" default:"6h"`
+	Lookback time.Duration `envconfig:"WATCH_LOOKBACK" default:"168h"` // how far back the first poll of a watch goes
+	MaxPosts int           `envconfig:"WATCH_MAX_POSTS" default:"30"`  // per watch and source
+	// how often due daily and weekly digests are looked for
+	DigestInterval time.Duration `envconfig:"WATCH_DIGEST_INTERVAL" default:"1h"`
+}
+
+// digest delivery, digests are only logged unless a provider is set
+type NotifyConfig struct {
+	Provider   string        `envconfig:"NOTIFY_PROVIDER" default:"log"` // log | webhook | smtp
+	WebhookURL string        `envconfig:"NOTIFY_WEBHOOK_URL"`
+	SMTPAddr   string        `envconfig:"NOTIFY_SMTP_ADDR"` // host:port
+	SMTPUser   string        `envconfig:"NOTIFY_SMTP_USER"`
+	SMTPPass   string        `envconfig:"NOTIFY_SMTP_PASS"`
+	From       string        `envconfig:"NOTIFY_FROM"`
+	Timeout    time.Duration `envconfig:"NOTIFY_TIMEOUT" default:"15s"`
+}
+
+// outbound requests of the source fetchers
+type FetchConfig struct {
+	UserAgent      string        `envconfig:"FETCH_USER_AGENT" default:"Mozilla/5.0 (compatible; interviewMin/1.0)"`
+	ConnectTimeout time.Duration `envconfig:"FETCH_CONNECT_TIMEOUT" default:"5s"`
+	ReadTimeout    time.Duration `envconfig:"FETCH_READ_TIMEOUT" default:"15s"` // until the response headers arrive
+	RequestTimeout time.Duration `envconfig:"FETCH_REQUEST_TIMEOUT" default:"30s"`
+	MaxBodyBytes   int64         `envconfig:"FETCH_MAX_BODY_BYTES" default:"5242880"`
+	MaxRetries     int           `envconfig:"FETCH_MAX_RETRIES" default:"3"` // on 429 and 5xx responses
+	RetryBaseDelay time.Duration `envconfig:"FETCH_RETRY_BASE_DELAY" default:"500ms"`
+	RetryMaxDelay  time.Duration `envconfig:"FETCH_RETRY_MAX_DELAY" default:"30s"`
+	MaxRedirects   int           `envconfig:"FETCH_MAX_REDIRECTS" default:"5"`
+	// lets fetches reach private and loopback addresses, only for tests against a local server
+	AllowPrivateNetworks bool `envconfig:"FETCH_ALLOW_PRIVATE_NETWORKS" default:"false"`
+}
+
+// storage of the fetch cache and of the raw snapshots of imported posts
+type ArchiveConfig struct {
+	Backend  string        `envconfig:"ARCHIVE_BACKEND" default:"fs"` // fs | postgres
+	Dir      string        `envconfig:"ARCHIVE_DIR" default:"data/archive"`
+	CacheTTL time.Duration `envconfig:"FETCH_CACHE_TTL" default:"1h"` // 0 turns the fetch cache off
+	// how often expired fetch cache entries are deleted
+	CacheSweepInterval time.Duration `envconfig:"FETCH_CACHE_SWEEP_INTERVAL" default:"1h"`
+}
+
+// scraping rules for sites without a dedicated fetcher, on top of the rules in the database
+type ScrapeConfig struct {
+	RulesFile string `envconfig:"SCRAPE_RULES_FILE"` // YAML file with a top level "rules" list
+}
+
+// inbound email ingestion, off unless a domain is set
+type IngestConfig struct {
+	Domain          string `envconfig:"INGEST_EMAIL_DOMAIN"`   // addresses are <token>@<domain>
+	WebhookSecret   string `envconfig:"INGEST_WEBHOOK_SECRET"` // expected in the X-Ingest-Secret header
+	MaxMessageBytes int64  `envconfig:"INGEST_MAX_MESSAGE_BYTES" default:"10485760"`
+}
+
 // Load reads configuration from environment variables
 func Load() (*Config, error) {
 	var cfg Config
@@ -119,6 +211,9 @@ func (c *Config) Validate() error {
 	if secretLen != 16 && secretLen != 24 && secretLen != 32 {
 		return fmt.Errorf("AES_SECRET_KEY must be 16, 24, or 32 bytes (got %d)", secretLen)
 	}
+	if c.Ingest.Domain != "" && c.Ingest.WebhookSecret == "" {
+		return fmt.Errorf("INGEST_WEBHOOK_SECRET is required when INGEST_EMAIL_DOMAIN is set")
+	}
 	if len(c.CORS.TrustedOrigins) == 0 {
 		return fmt.Errorf("at least one trusted origin must be specified")
 	}
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_question_tags_tag;
+DROP TABLE IF EXISTS question_tags;
+DROP INDEX IF EXISTS idx_interview_tags_tag;
+DROP TABLE IF EXISTS interview_tags;
+DROP INDEX IF EXISTS idx_tags_user_id;
+DROP TRIGGER IF EXISTS trigger_update_tags ON tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS tags (
+    tag_id      BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id     UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    name        VARCHAR(100) NOT NULL,
+    slug        VARCHAR(100) NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, slug)
+);
+
+CREATE INDEX idx_tags_user_id ON tags(user_id);
+
+CREATE TRIGGER trigger_update_tags
+BEFORE UPDATE ON tags
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS interview_tags (
+    interview_id BIGINT NOT NULL REFERENCES interviews(interview_id) ON DELETE CASCADE,
+    tag_id       BIGINT NOT NULL REFERENCES tags(tag_id) ON DELETE CASCADE,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (interview_id, tag_id)
+);
+
+CREATE INDEX idx_interview_tags_tag ON interview_tags(tag_id);
+
+CREATE TABLE IF NOT EXISTS question_tags (
+    q_id        BIGINT NOT NULL REFERENCES questions(q_id) ON DELETE CASCADE,
+    tag_id      BIGINT NOT NULL REFERENCES tags(tag_id) ON DELETE CASCADE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (q_id, tag_id)
+);
+
+CREATE INDEX idx_question_tags_tag ON question_tags(tag_id);
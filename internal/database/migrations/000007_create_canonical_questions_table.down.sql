@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_questions_cq;
+ALTER TABLE questions DROP COLUMN IF EXISTS cq_id;
+ALTER TABLE questions DROP COLUMN IF EXISTS updated_at;
+DROP INDEX IF EXISTS idx_canonical_questions_user_type;
+DROP TRIGGER IF EXISTS trigger_update_canonical_questions ON canonical_questions;
+DROP TABLE IF EXISTS canonical_questions;
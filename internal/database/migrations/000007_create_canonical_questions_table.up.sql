@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS canonical_questions (
+    cq_id       BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id     UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    question    TEXT NOT NULL,                      -- representative wording
+    normalized  TEXT NOT NULL,                      -- matching key
+    type        TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, normalized)
+);
+
+CREATE INDEX idx_canonical_questions_user_type ON canonical_questions(user_id, type);
+
+CREATE TRIGGER trigger_update_canonical_questions
+BEFORE UPDATE ON canonical_questions
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- the questions update trigger expects this column
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS cq_id BIGINT REFERENCES canonical_questions(cq_id) ON DELETE SET NULL;
+
+CREATE INDEX idx_questions_cq ON questions(cq_id);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_questions_lc_slug;
+ALTER TABLE questions
+    DROP COLUMN IF EXISTS lc_slug,
+    DROP COLUMN IF EXISTS lc_title,
+    DROP COLUMN IF EXISTS lc_difficulty,
+    DROP COLUMN IF EXISTS lc_topics,
+    DROP COLUMN IF EXISTS lc_score,
+    DROP COLUMN IF EXISTS lc_status;
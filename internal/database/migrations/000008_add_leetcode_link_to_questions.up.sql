@@ -0,0 +1,9 @@
+ALTER TABLE questions
+    ADD COLUMN IF NOT EXISTS lc_slug       TEXT,
+    ADD COLUMN IF NOT EXISTS lc_title      TEXT,
+    ADD COLUMN IF NOT EXISTS lc_difficulty VARCHAR(10),
+    ADD COLUMN IF NOT EXISTS lc_topics     TEXT[],
+    ADD COLUMN IF NOT EXISTS lc_score      REAL,
+    ADD COLUMN IF NOT EXISTS lc_status     VARCHAR(20); -- suggested | confirmed | rejected | unmatched
+
+CREATE INDEX IF NOT EXISTS idx_questions_lc_slug ON questions(lc_slug);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_question_reviews_user_q;
+DROP TABLE IF EXISTS question_reviews;
+DROP INDEX IF EXISTS idx_question_progress_due;
+DROP TRIGGER IF EXISTS trigger_update_question_progress ON question_progress;
+DROP TABLE IF EXISTS question_progress;
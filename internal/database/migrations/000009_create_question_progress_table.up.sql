@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS question_progress (
+    user_id          UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    q_id             BIGINT NOT NULL REFERENCES questions(q_id) ON DELETE CASCADE,
+
+    status           VARCHAR(20) NOT NULL DEFAULT 'unseen', -- unseen | attempted | solved | review
+    confidence       SMALLINT,                              -- 1..5, self rated
+
+    -- SM-2 scheduling state
+    ease_factor      REAL NOT NULL DEFAULT 2.5,
+    interval_days    INT NOT NULL DEFAULT 0,
+    repetitions      INT NOT NULL DEFAULT 0,
+    due_at           TIMESTAMPTZ,
+    last_reviewed_at TIMESTAMPTZ,
+    review_count     INT NOT NULL DEFAULT 0,
+
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, q_id)
+);
+
+CREATE INDEX idx_question_progress_due ON question_progress(user_id, due_at);
+
+CREATE TRIGGER trigger_update_question_progress
+BEFORE UPDATE ON question_progress
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS question_reviews (
+    review_id    BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id      UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    q_id         BIGINT NOT NULL REFERENCES questions(q_id) ON DELETE CASCADE,
+    quality      SMALLINT NOT NULL,                     -- 0..5 recall grade
+    confidence   SMALLINT,
+    reviewed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_question_reviews_user_q ON question_reviews(user_id, q_id);
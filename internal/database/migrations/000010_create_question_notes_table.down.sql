@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_questions_text_tsv;
+DROP INDEX IF EXISTS idx_question_notes_tsv;
+DROP INDEX IF EXISTS idx_question_notes_user;
+DROP TRIGGER IF EXISTS trigger_update_question_notes ON question_notes;
+DROP TRIGGER IF EXISTS question_notes_tsv_update ON question_notes;
+DROP FUNCTION IF EXISTS question_notes_tsv_trigger();
+DROP TABLE IF EXISTS question_note_revisions;
+DROP TABLE IF EXISTS question_notes;
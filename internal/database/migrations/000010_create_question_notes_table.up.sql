@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS question_notes (
+    q_id        BIGINT PRIMARY KEY REFERENCES questions(q_id) ON DELETE CASCADE,
+    user_id     UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    content     TEXT NOT NULL DEFAULT '',          -- markdown answer / notes
+    snippets    JSONB NOT NULL DEFAULT '[]',       -- [{language, title, code}]
+    revision    INT NOT NULL DEFAULT 1,
+    search_tsv  TSVECTOR NOT NULL DEFAULT ''::tsvector,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS question_note_revisions (
+    revision_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    q_id        BIGINT NOT NULL REFERENCES questions(q_id) ON DELETE CASCADE,
+    revision    INT NOT NULL,
+    content     TEXT NOT NULL,
+    snippets    JSONB NOT NULL DEFAULT '[]',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (q_id, revision)
+);
+
+CREATE OR REPLACE FUNCTION question_notes_tsv_trigger() RETURNS trigger AS $$
+BEGIN
+  NEW.search_tsv := to_tsvector('english', coalesce(NEW.content,''));
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER question_notes_tsv_update
+BEFORE INSERT OR UPDATE ON question_notes
+FOR EACH ROW EXECUTE FUNCTION question_notes_tsv_trigger();
+
+CREATE TRIGGER trigger_update_question_notes
+BEFORE UPDATE ON question_notes
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE INDEX IF NOT EXISTS idx_question_notes_user ON question_notes(user_id);
+CREATE INDEX IF NOT EXISTS idx_question_notes_tsv ON question_notes USING GIN(search_tsv);
+CREATE INDEX IF NOT EXISTS idx_questions_text_tsv ON questions USING GIN(to_tsvector('english', question));
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_mock_interview_questions_mock;
+DROP TABLE IF EXISTS mock_interview_questions;
+DROP INDEX IF EXISTS idx_mock_interviews_user;
+DROP TRIGGER IF EXISTS trigger_update_mock_interviews ON mock_interviews;
+DROP TABLE IF EXISTS mock_interviews;
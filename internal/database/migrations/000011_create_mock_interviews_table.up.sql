@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS mock_interviews (
+    mock_id     BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id     UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    company_id  UUID NOT NULL REFERENCES companies(company_id) ON DELETE CASCADE,
+    title       VARCHAR(255) NOT NULL,
+    status      VARCHAR(20) NOT NULL DEFAULT 'in_progress', -- in_progress | completed
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_mock_interviews_user ON mock_interviews(user_id);
+
+CREATE TRIGGER trigger_update_mock_interviews
+BEFORE UPDATE ON mock_interviews
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS mock_interview_questions (
+    mq_id        BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    mock_id      BIGINT NOT NULL REFERENCES mock_interviews(mock_id) ON DELETE CASCADE,
+    round_no     INT NOT NULL,
+    round_name   VARCHAR(255) NOT NULL,
+    position     INT NOT NULL,
+    question     TEXT NOT NULL,
+    type         TEXT NOT NULL,
+    q_id         BIGINT REFERENCES questions(q_id) ON DELETE SET NULL, -- source question, if any
+    times_asked  INT NOT NULL DEFAULT 0,
+    answer       TEXT,
+    feedback     JSONB,
+    score        INT,
+    answered_at  TIMESTAMPTZ
+);
+
+CREATE INDEX idx_mock_interview_questions_mock ON mock_interview_questions(mock_id);
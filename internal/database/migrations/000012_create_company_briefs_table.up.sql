@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS company_briefs (
+    company_id       UUID PRIMARY KEY REFERENCES companies(company_id) ON DELETE CASCADE,
+    user_id          UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    brief            JSONB NOT NULL,
+    fingerprint      TEXT NOT NULL, -- hash of the interviews the brief was generated from
+    interview_count  INT NOT NULL,
+    generated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,26 @@
+DROP INDEX IF EXISTS idx_companies_name_tsv;
+
+DROP TRIGGER IF EXISTS companies_interview_tsv_update ON companies;
+DROP FUNCTION IF EXISTS companies_interview_tsv_trigger();
+DROP TRIGGER IF EXISTS questions_interview_tsv_update ON questions;
+DROP FUNCTION IF EXISTS questions_interview_tsv_trigger();
+
+DROP TRIGGER IF EXISTS trigger_update_interviews ON interviews;
+CREATE TRIGGER trigger_update_interviews
+BEFORE UPDATE ON interviews
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE OR REPLACE FUNCTION interviews_tsv_trigger() RETURNS trigger AS $$
+BEGIN
+  NEW.search_tsv :=
+    to_tsvector('english',
+      coalesce(NEW.position,'') || ' ' ||
+      coalesce(NEW.metadata->>'title','')
+    );
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS interview_search_tsv(BIGINT, UUID, TEXT, TEXT, JSONB);
+
+UPDATE interviews SET search_tsv = '';
@@ -0,0 +1,62 @@
+-- weighted search document for an interview:
+-- A title, position | B company, questions | C location | D full experience
+CREATE OR REPLACE FUNCTION interview_search_tsv(
+    p_interview_id BIGINT, p_company_id UUID, p_position TEXT, p_location TEXT, p_metadata JSONB
+) RETURNS tsvector AS $$
+  SELECT
+    setweight(to_tsvector('english', coalesce(p_metadata->>'title','')), 'A') ||
+    setweight(to_tsvector('english', coalesce(p_position,'')), 'A') ||
+    setweight(to_tsvector('english', coalesce((SELECT name FROM companies WHERE company_id = p_company_id),'')), 'B') ||
+    setweight(to_tsvector('english', coalesce((SELECT string_agg(question, ' ') FROM questions WHERE interview_id = p_interview_id),'')), 'B') ||
+    setweight(to_tsvector('english', coalesce(p_location,'')), 'C') ||
+    setweight(to_tsvector('english', coalesce(p_metadata->>'full_experience','')), 'D')
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION interviews_tsv_trigger() RETURNS trigger AS $$
+BEGIN
+  NEW.search_tsv := interview_search_tsv(NEW.interview_id, NEW.company_id, NEW.position, NEW.location, NEW.metadata);
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- refreshing search_tsv from the triggers below must not bump updated_at
+DROP TRIGGER IF EXISTS trigger_update_interviews ON interviews;
+CREATE TRIGGER trigger_update_interviews
+BEFORE UPDATE OF company_id, source, raw_input, process_status, process_error, attempts,
+    position, no_of_round, metadata, location ON interviews
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- questions are part of the interview document
+CREATE OR REPLACE FUNCTION questions_interview_tsv_trigger() RETURNS trigger AS $$
+BEGIN
+  IF TG_OP IN ('UPDATE', 'DELETE') THEN
+    UPDATE interviews SET search_tsv = '' WHERE interview_id = OLD.interview_id;
+  END IF;
+  IF TG_OP IN ('INSERT', 'UPDATE') AND (TG_OP = 'INSERT' OR NEW.interview_id IS DISTINCT FROM OLD.interview_id) THEN
+    UPDATE interviews SET search_tsv = '' WHERE interview_id = NEW.interview_id;
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER questions_interview_tsv_update
+AFTER INSERT OR UPDATE OF question, interview_id OR DELETE ON questions
+FOR EACH ROW EXECUTE FUNCTION questions_interview_tsv_trigger();
+
+-- and so is the company name
+CREATE OR REPLACE FUNCTION companies_interview_tsv_trigger() RETURNS trigger AS $$
+BEGIN
+  UPDATE interviews SET search_tsv = '' WHERE company_id = NEW.company_id;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER companies_interview_tsv_update
+AFTER UPDATE OF name ON companies
+FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+EXECUTE FUNCTION companies_interview_tsv_trigger();
+
+-- backfill, tsv_update recomputes the document
+UPDATE interviews SET search_tsv = '';
+
+CREATE INDEX IF NOT EXISTS idx_companies_name_tsv ON companies USING GIN(to_tsvector('english', name));
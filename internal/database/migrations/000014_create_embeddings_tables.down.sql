@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS question_embeddings;
+DROP TABLE IF EXISTS interview_embeddings;
@@ -0,0 +1,30 @@
+-- pgvector is optional, vectors are stored as REAL[] and cast to vector at query time when it is installed
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'vector') THEN
+    CREATE EXTENSION IF NOT EXISTS vector;
+  END IF;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS interview_embeddings (
+    interview_id  BIGINT PRIMARY KEY REFERENCES interviews(interview_id) ON DELETE CASCADE,
+    user_id       UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    provider      TEXT NOT NULL, -- provider and model, vectors are only comparable within one
+    embedding     REAL[] NOT NULL,
+    content_hash  TEXT NOT NULL, -- md5 of the embedded text, a mismatch means the embedding is outdated
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_interview_embeddings_user ON interview_embeddings(user_id, provider);
+
+CREATE TABLE IF NOT EXISTS question_embeddings (
+    q_id          BIGINT PRIMARY KEY REFERENCES questions(q_id) ON DELETE CASCADE,
+    user_id       UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    provider      TEXT NOT NULL,
+    embedding     REAL[] NOT NULL,
+    content_hash  TEXT NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_question_embeddings_user ON question_embeddings(user_id, provider);
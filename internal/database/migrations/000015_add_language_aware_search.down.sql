@@ -0,0 +1,33 @@
+DROP TRIGGER IF EXISTS trigger_update_interviews ON interviews;
+CREATE TRIGGER trigger_update_interviews
+BEFORE UPDATE OF company_id, source, raw_input, process_status, process_error, attempts,
+    position, no_of_round, metadata, location ON interviews
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP FUNCTION IF EXISTS interview_search_tsv(regconfig, BIGINT, UUID, TEXT, TEXT, JSONB);
+
+CREATE OR REPLACE FUNCTION interview_search_tsv(
+    p_interview_id BIGINT, p_company_id UUID, p_position TEXT, p_location TEXT, p_metadata JSONB
+) RETURNS tsvector AS $$
+  SELECT
+    setweight(to_tsvector('english', coalesce(p_metadata->>'title','')), 'A') ||
+    setweight(to_tsvector('english', coalesce(p_position,'')), 'A') ||
+    setweight(to_tsvector('english', coalesce((SELECT name FROM companies WHERE company_id = p_company_id),'')), 'B') ||
+    setweight(to_tsvector('english', coalesce((SELECT string_agg(question, ' ') FROM questions WHERE interview_id = p_interview_id),'')), 'B') ||
+    setweight(to_tsvector('english', coalesce(p_location,'')), 'C') ||
+    setweight(to_tsvector('english', coalesce(p_metadata->>'full_experience','')), 'D')
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION interviews_tsv_trigger() RETURNS trigger AS $$
+BEGIN
+  NEW.search_tsv := interview_search_tsv(NEW.interview_id, NEW.company_id, NEW.position, NEW.location, NEW.metadata);
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS search_config_for(TEXT);
+
+ALTER TABLE users DROP COLUMN IF EXISTS search_language;
+ALTER TABLE interviews DROP COLUMN IF EXISTS language;
+
+UPDATE interviews SET search_tsv = '';
@@ -0,0 +1,52 @@
+-- ISO 639-1 code detected from the experience text, 'und' when undetermined.
+-- Existing rows keep the english configuration they were indexed with.
+ALTER TABLE interviews ADD COLUMN IF NOT EXISTS language VARCHAR(16) NOT NULL DEFAULT 'en';
+
+-- preferred language for search queries
+ALTER TABLE users ADD COLUMN IF NOT EXISTS search_language VARCHAR(16) NOT NULL DEFAULT 'en';
+
+-- text search configuration for a language code, languages without stemming support use simple
+CREATE OR REPLACE FUNCTION search_config_for(p_language TEXT) RETURNS regconfig AS $$
+  SELECT CASE p_language
+    WHEN 'en' THEN 'english'
+    WHEN 'es' THEN 'spanish'
+    WHEN 'fr' THEN 'french'
+    WHEN 'de' THEN 'german'
+    WHEN 'pt' THEN 'portuguese'
+    WHEN 'it' THEN 'italian'
+    WHEN 'nl' THEN 'dutch'
+    WHEN 'ru' THEN 'russian'
+    ELSE 'simple'
+  END::regconfig
+$$ LANGUAGE sql IMMUTABLE;
+
+DROP FUNCTION IF EXISTS interview_search_tsv(BIGINT, UUID, TEXT, TEXT, JSONB);
+
+-- company names and questions are short and mostly english-ish, they use the same
+-- configuration as the experience so the whole document stems consistently
+CREATE OR REPLACE FUNCTION interview_search_tsv(
+    p_config regconfig, p_interview_id BIGINT, p_company_id UUID, p_position TEXT, p_location TEXT, p_metadata JSONB
+) RETURNS tsvector AS $$
+  SELECT
+    setweight(to_tsvector(p_config, coalesce(p_metadata->>'title','')), 'A') ||
+    setweight(to_tsvector(p_config, coalesce(p_position,'')), 'A') ||
+    setweight(to_tsvector(p_config, coalesce((SELECT name FROM companies WHERE company_id = p_company_id),'')), 'B') ||
+    setweight(to_tsvector(p_config, coalesce((SELECT string_agg(question, ' ') FROM questions WHERE interview_id = p_interview_id),'')), 'B') ||
+    setweight(to_tsvector(p_config, coalesce(p_location,'')), 'C') ||
+    setweight(to_tsvector(p_config, coalesce(p_metadata->>'full_experience','')), 'D')
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION interviews_tsv_trigger() RETURNS trigger AS $$
+BEGIN
+  NEW.search_tsv := interview_search_tsv(
+    search_config_for(NEW.language), NEW.interview_id, NEW.company_id, NEW.position, NEW.location, NEW.metadata
+  );
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_update_interviews ON interviews;
+CREATE TRIGGER trigger_update_interviews
+BEFORE UPDATE OF company_id, source, raw_input, process_status, process_error, attempts,
+    position, no_of_round, metadata, location, language ON interviews
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS import_jobs (
+    job_id             BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id            UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    format             VARCHAR(20) NOT NULL, -- csv | json
+    filename           TEXT NOT NULL DEFAULT '',
+    status             VARCHAR(20) NOT NULL DEFAULT 'queued', -- queued | running | completed | failed
+    dry_run            BOOLEAN NOT NULL DEFAULT false,
+    extract_questions  BOOLEAN NOT NULL DEFAULT false,
+    total_rows         INT NOT NULL DEFAULT 0,
+    processed_rows     INT NOT NULL DEFAULT 0,
+    created_count      INT NOT NULL DEFAULT 0,
+    error_count        INT NOT NULL DEFAULT 0,
+    errors             JSONB NOT NULL DEFAULT '[]', -- per row validation and processing errors
+    error              TEXT, -- set when the whole job failed
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at        TIMESTAMPTZ
+);
+
+CREATE INDEX idx_import_jobs_user ON import_jobs(user_id, created_at DESC);
+
+CREATE TRIGGER trigger_update_import_jobs
+BEFORE UPDATE ON import_jobs
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
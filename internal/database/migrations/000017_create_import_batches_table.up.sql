@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS import_batches (
+    batch_id     BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id      UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at  TIMESTAMPTZ
+);
+
+CREATE INDEX idx_import_batches_user ON import_batches(user_id, created_at DESC);
+
+CREATE TRIGGER trigger_update_import_batches
+BEFORE UPDATE ON import_batches
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS import_batch_items (
+    item_id       BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    batch_id      BIGINT NOT NULL REFERENCES import_batches(batch_id) ON DELETE CASCADE,
+    user_id       UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    url           TEXT NOT NULL,
+    canonical_url TEXT,
+    source        VARCHAR(50),
+    status        VARCHAR(20) NOT NULL DEFAULT 'queued', -- queued | processing | completed | failed | duplicate
+    interview_id  BIGINT REFERENCES interviews(interview_id) ON DELETE SET NULL,
+    error         TEXT,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_import_batch_items_batch ON import_batch_items(batch_id, item_id);
+CREATE INDEX idx_import_batch_items_canonical ON import_batch_items(user_id, canonical_url) WHERE status = 'completed';
+
+CREATE TRIGGER trigger_update_import_batch_items
+BEFORE UPDATE ON import_batch_items
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
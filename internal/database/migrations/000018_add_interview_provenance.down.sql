@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_interviews_user_canonical_url;
+
+ALTER TABLE interviews
+    DROP COLUMN IF EXISTS source_url,
+    DROP COLUMN IF EXISTS canonical_url,
+    DROP COLUMN IF EXISTS fetched_at,
+    DROP COLUMN IF EXISTS source_published_at,
+    DROP COLUMN IF EXISTS content_hash;
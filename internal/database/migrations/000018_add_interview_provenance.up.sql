@@ -0,0 +1,19 @@
+ALTER TABLE interviews
+    ADD COLUMN IF NOT EXISTS source_url          TEXT,
+    ADD COLUMN IF NOT EXISTS canonical_url       TEXT,
+    ADD COLUMN IF NOT EXISTS fetched_at          TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS source_published_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS content_hash        TEXT; -- sha256 of the fetched title and content
+
+-- interviews imported through a batch already know their url
+UPDATE interviews i SET source_url = b.url, canonical_url = b.canonical_url, fetched_at = b.updated_at
+FROM (
+    SELECT DISTINCT ON (user_id, canonical_url) interview_id, url, canonical_url, updated_at
+    FROM import_batch_items
+    WHERE status = 'completed' AND interview_id IS NOT NULL AND canonical_url IS NOT NULL
+    ORDER BY user_id, canonical_url, item_id
+) b
+WHERE i.interview_id = b.interview_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_interviews_user_canonical_url
+    ON interviews(user_id, canonical_url) WHERE canonical_url IS NOT NULL;
@@ -0,0 +1,5 @@
+-- set when the user writes or edits a question, resync keeps these questions as they are
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS edited_at TIMESTAMPTZ;
+
+-- updated_at also moves on automatic linking, so treat every changed question as edited
+UPDATE questions SET edited_at = updated_at WHERE updated_at > created_at + INTERVAL '1 second';
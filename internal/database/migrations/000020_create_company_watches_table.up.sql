@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS company_watches (
+    watch_id        BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id         UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    company         TEXT NOT NULL,
+    leetcode_tag    TEXT NOT NULL, -- discuss tag slug of the company
+    last_polled_at  TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, leetcode_tag)
+);
+
+CREATE INDEX idx_company_watches_polled ON company_watches(last_polled_at NULLS FIRST);
+
+CREATE TRIGGER trigger_update_company_watches
+BEFORE UPDATE ON company_watches
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
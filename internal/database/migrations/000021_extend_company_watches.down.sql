@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS watch_matches;
+
+DROP INDEX IF EXISTS idx_company_watches_unique;
+
+ALTER TABLE company_watches
+    DROP COLUMN IF EXISTS last_digest_at,
+    DROP COLUMN IF EXISTS digest,
+    DROP COLUMN IF EXISTS sources,
+    DROP COLUMN IF EXISTS position_keyword;
+
+-- watches of the same company for different positions share a tag, only the oldest fits the old constraint
+DELETE FROM company_watches a
+USING company_watches b
+WHERE a.user_id = b.user_id AND a.leetcode_tag = b.leetcode_tag AND a.watch_id > b.watch_id;
+
+ALTER TABLE company_watches ADD CONSTRAINT company_watches_user_id_leetcode_tag_key UNIQUE (user_id, leetcode_tag);
@@ -0,0 +1,28 @@
+ALTER TABLE company_watches
+    ADD COLUMN position_keyword TEXT,
+    ADD COLUMN sources          JSONB NOT NULL DEFAULT '{"leetcode": true}', -- {"leetcode": bool, "subreddits": [], "gfg_pages": []}
+    ADD COLUMN digest           VARCHAR(10) NOT NULL DEFAULT 'daily', -- daily | weekly | off
+    ADD COLUMN last_digest_at   TIMESTAMPTZ;
+
+ALTER TABLE company_watches DROP CONSTRAINT IF EXISTS company_watches_user_id_leetcode_tag_key;
+
+CREATE UNIQUE INDEX idx_company_watches_unique
+ON company_watches(user_id, company, COALESCE(position_keyword, ''));
+
+-- posts found by a watch, reported in the next digest
+CREATE TABLE IF NOT EXISTS watch_matches (
+    match_id       BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    watch_id       BIGINT NOT NULL REFERENCES company_watches(watch_id) ON DELETE CASCADE,
+    user_id        UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    source         VARCHAR(20) NOT NULL,
+    url            TEXT NOT NULL,
+    canonical_url  TEXT NOT NULL,
+    title          TEXT NOT NULL DEFAULT '',
+    posted_at      TIMESTAMPTZ,
+    batch_id       BIGINT REFERENCES import_batches(batch_id) ON DELETE SET NULL,
+    notified_at    TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (watch_id, canonical_url)
+);
+
+CREATE INDEX idx_watch_matches_pending ON watch_matches(watch_id) WHERE notified_at IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS interview_snapshots;
+DROP TABLE IF EXISTS archive_blobs;
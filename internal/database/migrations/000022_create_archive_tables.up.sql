@@ -0,0 +1,18 @@
+-- blobs of the postgres archive backend: cached fetch responses and raw post snapshots
+CREATE TABLE IF NOT EXISTS archive_blobs (
+    key           TEXT PRIMARY KEY, -- cache/<request digest> | snapshots/<content digest>
+    content_type  TEXT NOT NULL DEFAULT '',
+    data          BYTEA NOT NULL,
+    stored_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- raw response a fetched interview was extracted from, kept when the post is deleted upstream
+CREATE TABLE IF NOT EXISTS interview_snapshots (
+    interview_id  BIGINT PRIMARY KEY REFERENCES interviews(interview_id) ON DELETE CASCADE,
+    blob_key      TEXT NOT NULL,
+    content_type  TEXT NOT NULL DEFAULT '',
+    size          INT NOT NULL,
+    source_url    TEXT NOT NULL,
+    fetched_at    TIMESTAMPTZ NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
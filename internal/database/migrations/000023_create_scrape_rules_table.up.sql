@@ -0,0 +1,18 @@
+-- admin managed rules for reading posts of sites without a dedicated fetcher
+CREATE TABLE IF NOT EXISTS scrape_rules (
+    rule_id           BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name              TEXT NOT NULL UNIQUE,
+    host_pattern      TEXT NOT NULL,            -- example.com or *.example.com
+    path_pattern      TEXT NOT NULL DEFAULT '', -- regular expression, empty matches every path
+    title_selector    TEXT NOT NULL DEFAULT '',
+    content_selector  TEXT NOT NULL,
+    date_selector     TEXT NOT NULL DEFAULT '',
+    remove_selectors  TEXT[] NOT NULL DEFAULT '{}',
+    enabled           BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER trigger_update_scrape_rules
+BEFORE UPDATE ON scrape_rules
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,13 @@
+-- original files of interviews created from uploads
+CREATE TABLE IF NOT EXISTS interview_attachments (
+    attachment_id  BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    interview_id   BIGINT NOT NULL REFERENCES interviews(interview_id) ON DELETE CASCADE,
+    user_id        UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    filename       TEXT NOT NULL,
+    content_type   TEXT NOT NULL,
+    size           INT NOT NULL,
+    blob_key       TEXT NOT NULL, -- key in the archive store
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_interview_attachments_interview ON interview_attachments(interview_id);
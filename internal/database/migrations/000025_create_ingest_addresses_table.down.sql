@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ingested_emails;
+DROP TABLE IF EXISTS ingest_addresses;
@@ -0,0 +1,14 @@
+-- per user addresses interview notes can be emailed to
+CREATE TABLE IF NOT EXISTS ingest_addresses (
+    user_id     UUID PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    token       TEXT NOT NULL UNIQUE, -- local part of the address
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- messages already ingested, mail providers retry webhook deliveries
+CREATE TABLE IF NOT EXISTS ingested_emails (
+    user_id      UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    message_id   TEXT NOT NULL,
+    received_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, message_id)
+);
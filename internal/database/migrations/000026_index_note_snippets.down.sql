@@ -0,0 +1,8 @@
+CREATE OR REPLACE FUNCTION question_notes_tsv_trigger() RETURNS trigger AS $$
+BEGIN
+  NEW.search_tsv := to_tsvector('english', coalesce(NEW.content,''));
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+UPDATE question_notes SET search_tsv = '';
@@ -0,0 +1,14 @@
+-- notes are searched together with the titles and code of their snippets
+CREATE OR REPLACE FUNCTION question_notes_tsv_trigger() RETURNS trigger AS $$
+BEGIN
+  NEW.search_tsv :=
+    setweight(to_tsvector('english', coalesce(NEW.content,'')), 'A') ||
+    setweight(to_tsvector('english', coalesce((
+      SELECT string_agg(coalesce(s->>'title','') || ' ' || coalesce(s->>'code',''), ' ')
+      FROM jsonb_array_elements(NEW.snippets) AS s
+    ),'')), 'B');
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+UPDATE question_notes SET search_tsv = '';
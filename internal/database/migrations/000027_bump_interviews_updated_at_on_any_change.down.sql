@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS trigger_update_interviews ON interviews;
+CREATE TRIGGER trigger_update_interviews
+BEFORE UPDATE OF company_id, source, raw_input, process_status, process_error, attempts,
+    position, no_of_round, metadata, location, language ON interviews
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP FUNCTION IF EXISTS interviews_updated_at_trigger();
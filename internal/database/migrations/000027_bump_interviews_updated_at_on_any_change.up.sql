@@ -0,0 +1,15 @@
+-- refreshing search_tsv must not bump updated_at, any other change does, including
+-- columns added after the trigger's column list was written
+CREATE OR REPLACE FUNCTION interviews_updated_at_trigger() RETURNS trigger AS $$
+BEGIN
+  IF (to_jsonb(NEW) - 'search_tsv' - 'updated_at') IS DISTINCT FROM (to_jsonb(OLD) - 'search_tsv' - 'updated_at') THEN
+    NEW.updated_at := NOW();
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_update_interviews ON interviews;
+CREATE TRIGGER trigger_update_interviews
+BEFORE UPDATE ON interviews
+FOR EACH ROW EXECUTE FUNCTION interviews_updated_at_trigger();
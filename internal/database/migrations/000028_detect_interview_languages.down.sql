@@ -0,0 +1,27 @@
+DROP TRIGGER IF EXISTS interviews_language_notes_update ON interviews;
+DROP FUNCTION IF EXISTS interviews_language_notes_trigger();
+
+UPDATE interviews SET language = 'en' WHERE language = '';
+
+CREATE OR REPLACE FUNCTION interviews_updated_at_trigger() RETURNS trigger AS $$
+BEGIN
+  IF (to_jsonb(NEW) - 'search_tsv' - 'updated_at') IS DISTINCT FROM (to_jsonb(OLD) - 'search_tsv' - 'updated_at') THEN
+    NEW.updated_at := NOW();
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION question_notes_tsv_trigger() RETURNS trigger AS $$
+BEGIN
+  NEW.search_tsv :=
+    setweight(to_tsvector('english', coalesce(NEW.content,'')), 'A') ||
+    setweight(to_tsvector('english', coalesce((
+      SELECT string_agg(coalesce(s->>'title','') || ' ' || coalesce(s->>'code',''), ' ')
+      FROM jsonb_array_elements(NEW.snippets) AS s
+    ),'')), 'B');
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+UPDATE question_notes SET search_tsv = '';
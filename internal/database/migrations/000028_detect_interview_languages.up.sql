@@ -0,0 +1,47 @@
+-- notes are indexed in the language of their question's interview
+CREATE OR REPLACE FUNCTION question_notes_tsv_trigger() RETURNS trigger AS $$
+DECLARE
+  cfg regconfig;
+BEGIN
+  SELECT search_config_for(i.language) INTO cfg
+  FROM questions q INNER JOIN interviews i ON i.interview_id = q.interview_id
+  WHERE q.q_id = NEW.q_id;
+  cfg := coalesce(cfg, 'simple'::regconfig);
+
+  NEW.search_tsv :=
+    setweight(to_tsvector(cfg, coalesce(NEW.content,'')), 'A') ||
+    setweight(to_tsvector(cfg, coalesce((
+      SELECT string_agg(coalesce(s->>'title','') || ' ' || coalesce(s->>'code',''), ' ')
+      FROM jsonb_array_elements(NEW.snippets) AS s
+    ),'')), 'B');
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION interviews_language_notes_trigger() RETURNS trigger AS $$
+BEGIN
+  UPDATE question_notes SET search_tsv = ''
+  WHERE q_id IN (SELECT q_id FROM questions WHERE interview_id = NEW.interview_id);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER interviews_language_notes_update
+AFTER UPDATE OF language ON interviews
+FOR EACH ROW WHEN (OLD.language IS DISTINCT FROM NEW.language)
+EXECUTE FUNCTION interviews_language_notes_trigger();
+
+-- the language is derived from the text, detecting it is no change to the interview
+CREATE OR REPLACE FUNCTION interviews_updated_at_trigger() RETURNS trigger AS $$
+BEGIN
+  IF (to_jsonb(NEW) - 'search_tsv' - 'updated_at' - 'language') IS DISTINCT FROM (to_jsonb(OLD) - 'search_tsv' - 'updated_at' - 'language') THEN
+    NEW.updated_at := NOW();
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- rows created before the language column got 'en' without being looked at. An empty
+-- language marks them for detection, the API detects them at startup and the triggers
+-- reindex each one as it is updated. Until then they are indexed without stemming.
+UPDATE interviews SET language = '';
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_archive_blobs_stored_at;
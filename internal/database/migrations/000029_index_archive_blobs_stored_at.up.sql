@@ -0,0 +1,2 @@
+-- expired fetch cache entries are swept by age
+CREATE INDEX IF NOT EXISTS idx_archive_blobs_stored_at ON archive_blobs(stored_at);
@@ -0,0 +1 @@
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS edited_at TIMESTAMPTZ;
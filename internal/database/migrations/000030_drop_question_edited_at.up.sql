@@ -0,0 +1,2 @@
+-- resync only adds questions, nothing reads edited_at anymore
+ALTER TABLE questions DROP COLUMN IF EXISTS edited_at;
@@ -0,0 +1,111 @@
+// Package document extracts plain text from uploaded interview notes
+package document
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kind is a supported document format
+type Kind string
+
+const (
+	KindPDF      Kind = "pdf"
+	KindDOCX     Kind = "docx"
+	KindMarkdown Kind = "markdown"
+	KindText     Kind = "text"
+)
+
+var (
+	ErrUnsupported = errors.New("unsupported file type, upload a pdf, docx, md or txt file")
+	ErrNoText      = errors.New("no text found in file")
+)
+
+// ContentType is the MIME type a file of the kind is served with
+func (k Kind) ContentType() string {
+	switch k {
+	case KindPDF:
+		return "application/pdf"
+	case KindDOCX:
+		return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	case KindMarkdown:
+		return "text/markdown; charset=utf-8"
+	}
+	return "text/plain; charset=utf-8"
+}
+
+// Detect tells the kind of a file from its name, checked against its content
+func Detect(filename string, data []byte) (Kind, error) {
+	sniffed := http.DetectContentType(data)
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".pdf":
+		if bytes.HasPrefix(data, []byte("%PDF-")) {
+			return KindPDF, nil
+		}
+	case ".docx":
+		if sniffed == "application/zip" {
+			return KindDOCX, nil
+		}
+	case ".md", ".markdown":
+		if utf8.Valid(data) {
+			return KindMarkdown, nil
+		}
+	case ".txt", ".text", "":
+		if utf8.Valid(data) {
+			return KindText, nil
+		}
+	default:
+		return "", ErrUnsupported
+	}
+	return "", fmt.Errorf("file content does not match its %s extension", filepath.Ext(filename))
+}
+
+// ExtractText returns the text of a document, one paragraph per line
+func ExtractText(kind Kind, data []byte) (string, error) {
+	var text string
+	var err error
+	switch kind {
+	case KindPDF:
+		text, err = pdfText(data)
+	case KindDOCX:
+		text, err = docxText(data)
+	case KindMarkdown, KindText:
+		text = strings.TrimPrefix(string(data), "\ufeff")
+	default:
+		return "", ErrUnsupported
+	}
+	if err != nil {
+		return "", err
+	}
+
+	text = normalize(text)
+	if text == "" {
+		return "", ErrNoText
+	}
+	return text, nil
+}
+
+// normalize trims every line and collapses runs of blank lines
+func normalize(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	var out []string
+	blank := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t\u00a0")
+		if strings.TrimSpace(line) == "" {
+			blank = len(out) > 0
+			continue
+		}
+		if blank {
+			out = append(out, "")
+			blank = false
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
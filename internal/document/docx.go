@@ -0,0 +1,86 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxDocxXML caps the uncompressed document part, a guard against zip bombs
+const maxDocxXML = 20 << 20
+
+// docxText reads the paragraphs of word/document.xml. Tabs and line breaks inside a
+// paragraph are kept, list items get a leading dash.
+func docxText(data []byte) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("read docx: %w", err)
+	}
+
+	var part *zip.File
+	for _, f := range zr.File {
+		if f.Name == "word/document.xml" {
+			part = f
+			break
+		}
+	}
+	if part == nil {
+		return "", errors.New("read docx: word/document.xml not found")
+	}
+	rc, err := part.Open()
+	if err != nil {
+		return "", fmt.Errorf("read docx: %w", err)
+	}
+	defer rc.Close()
+
+	dec := xml.NewDecoder(io.LimitReader(rc, maxDocxXML))
+	var b, para strings.Builder
+	inText, listItem := false, false
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("read docx: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				para.WriteString("\t")
+			case "br", "cr":
+				para.WriteString("\n")
+			case "numPr":
+				listItem = true
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				if text := strings.TrimSpace(para.String()); text != "" {
+					if listItem {
+						b.WriteString("- ")
+					}
+					b.WriteString(text)
+				}
+				b.WriteString("\n")
+				para.Reset()
+				listItem = false
+			}
+		case xml.CharData:
+			if inText {
+				para.Write(t)
+			}
+		}
+	}
+	return b.String(), nil
+}
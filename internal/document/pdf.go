@@ -0,0 +1,86 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// pdfText reads the text of every page line by line, top to bottom
+func pdfText(data []byte) (text string, err error) {
+	// the reader panics on some malformed files
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("read pdf: %v", r)
+		}
+	}()
+
+	r, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("read pdf: %w", err)
+	}
+
+	var b strings.Builder
+	for i := 1; i <= r.NumPage(); i++ {
+		page := r.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+		for _, line := range pdfLines(page.Content().Text) {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// pdfLines groups positioned glyphs into lines. Glyphs on about the same baseline form a
+// line, a gap wider than a third of the font size between two glyphs is a space.
+func pdfLines(texts []pdf.Text) []string {
+	type line struct {
+		y     float64
+		texts []pdf.Text
+	}
+	var lines []*line
+	for _, t := range texts {
+		if t.S == "" {
+			continue
+		}
+		tolerance := math.Max(t.FontSize/3, 1)
+		var found *line
+		for _, l := range lines {
+			if math.Abs(l.y-t.Y) <= tolerance {
+				found = l
+				break
+			}
+		}
+		if found == nil {
+			found = &line{y: t.Y}
+			lines = append(lines, found)
+		}
+		found.texts = append(found.texts, t)
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].y > lines[j].y })
+
+	out := make([]string, 0, len(lines))
+	for _, l := range lines {
+		sort.SliceStable(l.texts, func(i, j int) bool { return l.texts[i].X < l.texts[j].X })
+		var b strings.Builder
+		end := math.Inf(-1)
+		for _, t := range l.texts {
+			if b.Len() > 0 && t.X-end > t.FontSize/3 && !strings.HasSuffix(b.String(), " ") && t.S != " " {
+				b.WriteString(" ")
+			}
+			b.WriteString(t.S)
+			end = t.X + t.W
+		}
+		out = append(out, b.String())
+	}
+	return out
+}
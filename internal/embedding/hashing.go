@@ -0,0 +1,105 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const defaultHashingDimensions = 512
+
+// stopWords are too common to say anything about a text
+var stopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "to": true, "of": true, "for": true, "and": true,
+	"or": true, "in": true, "on": true, "with": true, "is": true, "are": true, "was": true,
+	"it": true, "i": true, "me": true, "we": true, "you": true, "that": true, "this": true,
+}
+
+// Hashing is a local provider that needs no model. It hashes words, word pairs and
+// character trigrams into a fixed size vector, so texts sharing vocabulary or word
+// fragments ("url shortener" vs "tinyurl") land close to each other.
+type Hashing struct {
+	dims int
+}
+
+func NewHashing(dims int) *Hashing {
+	if dims <= 0 {
+		dims = defaultHashingDimensions
+	}
+	return &Hashing{dims: dims}
+}
+
+func (h *Hashing) Name() string {
+	return fmt.Sprintf("hash-%d", h.dims)
+}
+
+func (h *Hashing) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, len(texts))
+	for i, text := range texts {
+		out[i] = h.vector(text)
+	}
+	return out, nil
+}
+
+func (h *Hashing) vector(text string) []float32 {
+	v := make([]float32, h.dims)
+	words := tokenize(text)
+
+	for i, w := range words {
+		h.add(v, "w:"+w, 1)
+		if i > 0 {
+			h.add(v, "b:"+words[i-1]+" "+w, 0.5)
+		}
+		padded := " " + w + " "
+		for j := 0; j+3 <= len(padded); j++ {
+			h.add(v, "t:"+padded[j:j+3], 0.5)
+		}
+	}
+
+	var norm float64
+	for _, x := range v {
+		norm += float64(x) * float64(x)
+	}
+	if norm > 0 {
+		scale := float32(1 / math.Sqrt(norm))
+		for i := range v {
+			v[i] *= scale
+		}
+	}
+	return v
+}
+
+// add hashes a feature into a bucket, a second hash bit picks the sign so that
+// collisions cancel out instead of piling up
+func (h *Hashing) add(v []float32, feature string, weight float32) {
+	f := fnv.New64a()
+	_, _ = f.Write([]byte(feature))
+	// fnv spreads short, similar strings poorly over the low bits, mix them first
+	sum := f.Sum64()
+	sum ^= sum >> 33
+	sum *= 0xff51afd7ed558ccd
+	sum ^= sum >> 33
+
+	idx := int(sum % uint64(h.dims))
+	if sum>>63 == 1 {
+		weight = -weight
+	}
+	v[idx] += weight
+}
+
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	out := fields[:0]
+	for _, f := range fields {
+		if !stopWords[f] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Provider turns texts into embedding vectors
+type Provider interface {
+	// Embed returns one vector per input text, in order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	// Name identifies the provider and model, vectors are only comparable within one name
+	Name() string
+}
+
+// Options selects and configures an embedding provider
+type Options struct {
+	Provider   string // openai | ollama | hash
+	BaseURL    string
+	APIKey     string
+	Model      string
+	Dimensions int
+	Timeout    time.Duration
+}
+
+// New returns the provider selected by opts.Provider
+func New(opts Options) (Provider, error) {
+	if opts.Timeout == 0 {
+		opts.Timeout = 30 * time.Second
+	}
+
+	switch opts.Provider {
+	case "", "hash":
+		return NewHashing(opts.Dimensions), nil
+	case "openai":
+		if opts.BaseURL == "" || opts.Model == "" {
+			return nil, fmt.Errorf("openai embedding provider needs a base url and model")
+		}
+		return NewOpenAI(opts.BaseURL, opts.APIKey, opts.Model, opts.Timeout), nil
+	case "ollama":
+		if opts.Model == "" {
+			return nil, fmt.Errorf("ollama embedding provider needs a model")
+		}
+		if opts.BaseURL == "" {
+			opts.BaseURL = "http://localhost:11434"
+		}
+		return NewOllama(opts.BaseURL, opts.Model, opts.Timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown embedding provider: %s", opts.Provider)
+	}
+}
+
+// Cosine returns the cosine similarity of two vectors, 0 when they can't be compared
+func Cosine(a, b []float32) float32 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return float32(dot / (math.Sqrt(na) * math.Sqrt(nb)))
+}
@@ -0,0 +1,230 @@
+// Package export writes a user's interviews into zip archives in JSON, CSV or Markdown
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhishek622/interviewMin/pkg"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/goccy/go-yaml"
+)
+
+// JSONFile is the name of the versioned dump inside a JSON export archive
+const JSONFile = "interviewmin.json"
+
+// Write writes the archive in the given format into zw
+func Write(zw *zip.Writer, a *model.ExportArchive, format model.ExportFormat) error {
+	switch format {
+	case model.ExportFormatJSON:
+		return writeJSON(zw, a)
+	case model.ExportFormatCSV:
+		return writeCSV(zw, a)
+	case model.ExportFormatMarkdown:
+		return writeMarkdown(zw, a)
+	default:
+		return fmt.Errorf("unknown export format: %s", format)
+	}
+}
+
+func writeJSON(zw *zip.Writer, a *model.ExportArchive) error {
+	w, err := zw.Create(JSONFile)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(a)
+}
+
+func writeCSV(zw *zip.Writer, a *model.ExportArchive) error {
+	w, err := zw.Create("interviews.csv")
+	if err != nil {
+		return err
+	}
+	iw := csv.NewWriter(w)
+	_ = iw.Write([]string{
+		"interview_id", "company", "source", "title", "position", "no_of_round", "location",
+		"language", "tags", "created_at", "source_url", "canonical_url", "fetched_at", "full_experience",
+	})
+	for _, iv := range a.Interviews {
+		_ = iw.Write([]string{
+			strconv.FormatInt(iv.InterviewID, 10), iv.Company, string(iv.Source), iv.Title,
+			deref(iv.Position), derefInt(iv.NoOfRound), deref(iv.Location), iv.Language,
+			strings.Join(iv.Tags, ";"), iv.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			deref(iv.SourceURL), deref(iv.CanonicalURL), formatTime(iv.FetchedAt), experience(iv),
+		})
+	}
+	iw.Flush()
+	if err := iw.Error(); err != nil {
+		return err
+	}
+
+	w, err = zw.Create("questions.csv")
+	if err != nil {
+		return err
+	}
+	qw := csv.NewWriter(w)
+	_ = qw.Write([]string{"q_id", "interview_id", "company", "question", "type", "tags", "leetcode_slug", "note"})
+	for _, iv := range a.Interviews {
+		for _, q := range iv.Questions {
+			_ = qw.Write([]string{
+				strconv.FormatInt(q.QID, 10), strconv.FormatInt(iv.InterviewID, 10), iv.Company,
+				q.Question, q.Type, strings.Join(q.Tags, ";"), deref(q.LeetcodeSlug), deref(q.Note),
+			})
+		}
+	}
+	qw.Flush()
+	return qw.Error()
+}
+
+// frontMatter is the YAML header of a Markdown interview file, laid out for Obsidian
+type frontMatter struct {
+	ID        int64    `yaml:"id"`
+	Company   string   `yaml:"company"`
+	Position  string   `yaml:"position,omitempty"`
+	Source    string   `yaml:"source"`
+	SourceURL string   `yaml:"source_url,omitempty"`
+	Location  string   `yaml:"location,omitempty"`
+	Rounds    int      `yaml:"rounds,omitempty"`
+	Language  string   `yaml:"language,omitempty"`
+	Date      string   `yaml:"date"`
+	Tags      []string `yaml:"tags,omitempty"`
+	Questions int      `yaml:"questions"`
+}
+
+var questionSections = []struct{ typ, title string }{
+	{"dsa", "DSA"},
+	{"system_design", "System Design"},
+	{"behavioral", "Behavioral"},
+	{"other", "Other"},
+}
+
+// writeMarkdown writes one file per interview under interviews/<company>/
+func writeMarkdown(zw *zip.Writer, a *model.ExportArchive) error {
+	for _, iv := range a.Interviews {
+		fm := frontMatter{
+			ID:        iv.InterviewID,
+			Company:   iv.Company,
+			Position:  deref(iv.Position),
+			Source:    string(iv.Source),
+			SourceURL: deref(iv.SourceURL),
+			Location:  deref(iv.Location),
+			Language:  iv.Language,
+			Date:      iv.CreatedAt.Format("2006-01-02"),
+			Questions: len(iv.Questions),
+		}
+		if iv.CanonicalURL != nil {
+			fm.SourceURL = *iv.CanonicalURL
+		}
+		if iv.NoOfRound != nil {
+			fm.Rounds = *iv.NoOfRound
+		}
+		for _, t := range iv.Tags {
+			// obsidian tags can't contain spaces
+			fm.Tags = append(fm.Tags, strings.ReplaceAll(t, " ", "-"))
+		}
+
+		header, err := yaml.Marshal(fm)
+		if err != nil {
+			return fmt.Errorf("marshal front matter: %w", err)
+		}
+
+		title := iv.Title
+		if title == "" {
+			title = fmt.Sprintf("%s interview", iv.Company)
+		}
+
+		var b strings.Builder
+		b.WriteString("---\n")
+		b.Write(header)
+		b.WriteString("---\n\n")
+		fmt.Fprintf(&b, "# %s\n\n", title)
+		if exp := experience(iv); exp != "" {
+			fmt.Fprintf(&b, "## Experience\n\n%s\n\n", strings.TrimSpace(exp))
+		}
+
+		if len(iv.Questions) > 0 {
+			b.WriteString("## Questions\n")
+			for _, section := range questionSections {
+				first := true
+				for _, q := range iv.Questions {
+					if q.Type != section.typ && (section.typ != "other" || isKnownType(q.Type)) {
+						continue
+					}
+					if first {
+						fmt.Fprintf(&b, "\n### %s\n\n", section.title)
+						first = false
+					}
+					writeMarkdownQuestion(&b, q)
+				}
+			}
+		}
+
+		name := fmt.Sprintf("interviews/%s/%s-%d-%s.md",
+			pkg.GenerateSlug(iv.Company), fm.Date, iv.InterviewID, pkg.GenerateSlug(title))
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte(b.String())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMarkdownQuestion(b *strings.Builder, q model.ExportQuestion) {
+	fmt.Fprintf(b, "- %s", strings.ReplaceAll(strings.TrimSpace(q.Question), "\n", " "))
+	if q.LeetcodeSlug != nil {
+		fmt.Fprintf(b, " ([LeetCode](https://leetcode.com/problems/%s/))", *q.LeetcodeSlug)
+	}
+	for _, t := range q.Tags {
+		fmt.Fprintf(b, " #%s", strings.ReplaceAll(t, " ", "-"))
+	}
+	b.WriteString("\n")
+
+	if q.Note != nil && strings.TrimSpace(*q.Note) != "" {
+		// indent the note so it renders as part of the list item
+		for _, line := range strings.Split(strings.TrimSpace(*q.Note), "\n") {
+			fmt.Fprintf(b, "    %s\n", line)
+		}
+	}
+}
+
+func isKnownType(t string) bool {
+	return t == "dsa" || t == "system_design" || t == "behavioral"
+}
+
+func experience(iv model.ExportInterview) string {
+	if iv.FullExperience != "" {
+		return iv.FullExperience
+	}
+	return iv.RawInput
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func derefInt(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
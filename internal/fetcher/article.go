@@ -0,0 +1,235 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+type ArticleResponse struct {
+	Title       string
+	URL         string
+	Content     string
+	PublishedAt *time.Time
+}
+
+var (
+	// class and id hints used to score article candidates, after Arc90's readability
+	unlikelyCandidates = regexp.MustCompile(`(?i)banner|breadcrumb|combx|comment|community|cookie|disqus|extra|footer|gdpr|header|menu|modal|newsletter|pager|pagination|popup|promo|related|remark|replies|rss|share|shoutbox|sidebar|skyscraper|social|sponsor|subscribe|tags|toolbar|widget|\bad-|\bads\b`)
+	maybeCandidate     = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+	positiveHints      = regexp.MustCompile(`(?i)article|blog|body|content|entry|h-entry|main|page|post|story|text`)
+	negativeHints      = regexp.MustCompile(`(?i)-ad-|byline|combx|comment|com-|contact|foot|footer|footnote|hidden|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|widget`)
+)
+
+// minArticleLength is the shortest extracted text accepted as an article
+const minArticleLength = 200
+
+// GetArticle downloads a web page and extracts its main article with a readability
+// style content score, the text has the same format as the GfG fetcher
+func GetArticle(ctx context.Context, pageURL string) (ArticleResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return ArticleResponse{}, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return ArticleResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ArticleResponse{}, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, req.URL.Host)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.Contains(ct, "html") {
+		return ArticleResponse{}, fmt.Errorf("unsupported content type %s", ct)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return ArticleResponse{}, err
+	}
+
+	res := ArticleResponse{
+		Title:       articleTitle(doc),
+		URL:         pageURL,
+		PublishedAt: articlePublishedAt(doc),
+	}
+	if canonical, ok := doc.Find(`link[rel="canonical"]`).Attr("href"); ok && strings.HasPrefix(canonical, "http") {
+		res.URL = canonical
+	}
+
+	res.Content = ExtractArticle(doc)
+	if len(res.Content) < minArticleLength {
+		return ArticleResponse{}, errors.New("no article content found on page")
+	}
+	return res, nil
+}
+
+// ExtractArticle finds the element holding the main text of a page and converts it to text
+func ExtractArticle(doc *goquery.Document) string {
+	doc.Find("script, style, noscript, iframe, form, nav, header, footer, aside, svg, button, input, select, textarea").Remove()
+
+	// drop blocks that are unlikely to be the article, unless they also look like content
+	doc.Find("body *").Each(func(i int, s *goquery.Selection) {
+		if goquery.NodeName(s) == "article" || goquery.NodeName(s) == "main" {
+			return
+		}
+		hints := classAndID(s)
+		if hints != "" && unlikelyCandidates.MatchString(hints) && !maybeCandidate.MatchString(hints) {
+			s.Remove()
+		}
+	})
+
+	scores := map[*html.Node]float64{}
+	var candidates []*goquery.Selection
+	addScore := func(s *goquery.Selection, score float64) {
+		if s.Length() == 0 || goquery.NodeName(s) == "body" || goquery.NodeName(s) == "html" {
+			return
+		}
+		node := s.Get(0)
+		if _, ok := scores[node]; !ok {
+			scores[node] = initialScore(s)
+			candidates = append(candidates, s)
+		}
+		scores[node] += score
+	}
+
+	doc.Find("p, pre, td, blockquote, li").Each(func(i int, s *goquery.Selection) {
+		text := strings.TrimSpace(s.Text())
+		if len(text) < 25 {
+			return
+		}
+		score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len(text))/100, 3)
+		addScore(s.Parent(), score)
+		addScore(s.Parent().Parent(), score/2)
+	})
+
+	var best *goquery.Selection
+	bestScore := 0.0
+	for _, s := range candidates {
+		score := scores[s.Get(0)] * (1 - linkDensity(s))
+		if score > bestScore {
+			best, bestScore = s, score
+		}
+	}
+	if best == nil {
+		best = doc.Find("article, main").First()
+		if best.Length() == 0 {
+			best = doc.Find("body")
+		}
+	}
+
+	const blocks = "h1, h2, h3, h4, h5, h6, p, ul, ol, pre"
+	var builder strings.Builder
+	// a list or code block can win on its own, it is then written as a whole
+	if best.Is(blocks) {
+		processElement(best, &builder)
+		return cleanFinalContent(builder.String())
+	}
+	best.Find(blocks).Each(func(i int, s *goquery.Selection) {
+		// lists and code inside other handled elements are written by their parent
+		if s.ParentsFiltered("ul, ol, pre").Length() > 0 {
+			return
+		}
+		if goquery.NodeName(s) == "p" && s.ParentsFiltered("li").Length() > 0 {
+			return
+		}
+		processElement(s, &builder)
+	})
+	return cleanFinalContent(builder.String())
+}
+
+func classAndID(s *goquery.Selection) string {
+	class, _ := s.Attr("class")
+	id, _ := s.Attr("id")
+	return strings.TrimSpace(class + " " + id)
+}
+
+// initialScore weights a candidate by its tag and its class and id hints
+func initialScore(s *goquery.Selection) float64 {
+	var score float64
+	switch goquery.NodeName(s) {
+	case "article", "main":
+		score = 10
+	case "div":
+		score = 5
+	case "pre", "td", "blockquote":
+		score = 3
+	case "address", "ol", "ul", "dl", "dd", "dt", "li", "form":
+		score = -3
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
+		score = -5
+	}
+
+	hints := classAndID(s)
+	if negativeHints.MatchString(hints) {
+		score -= 25
+	}
+	if positiveHints.MatchString(hints) {
+		score += 25
+	}
+	return score
+}
+
+// linkDensity is the share of an element's text that sits inside links
+func linkDensity(s *goquery.Selection) float64 {
+	textLen := len(strings.TrimSpace(s.Text()))
+	if textLen == 0 {
+		return 0
+	}
+	linkLen := 0
+	s.Find("a").Each(func(i int, a *goquery.Selection) {
+		linkLen += len(strings.TrimSpace(a.Text()))
+	})
+	return float64(linkLen) / float64(textLen)
+}
+
+func articleTitle(doc *goquery.Document) string {
+	if title, ok := doc.Find(`meta[property="og:title"]`).Attr("content"); ok && strings.TrimSpace(title) != "" {
+		return strings.TrimSpace(title)
+	}
+	if title := strings.TrimSpace(doc.Find("h1").First().Text()); title != "" {
+		return cleanInlineText(title)
+	}
+	return cleanInlineText(doc.Find("title").First().Text())
+}
+
+func articlePublishedAt(doc *goquery.Document) *time.Time {
+	var candidates []string
+	for _, sel := range []string{`meta[property="article:published_time"]`, `meta[name="date"]`, `meta[itemprop="datePublished"]`} {
+		if v, ok := doc.Find(sel).Attr("content"); ok {
+			candidates = append(candidates, v)
+		}
+	}
+	if v, ok := doc.Find("time[datetime]").First().Attr("datetime"); ok {
+		candidates = append(candidates, v)
+	}
+
+	for _, v := range candidates {
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05.000Z07:00", "2006-01-02"} {
+			if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return &t
+			}
+		}
+	}
+	return nil
+}
+
+// IsURL reports whether input is a single http(s) URL rather than pasted text
+func IsURL(input string) bool {
+	input = strings.TrimSpace(input)
+	if strings.ContainsAny(input, " \n\t") {
+		return false
+	}
+	return strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://")
+}
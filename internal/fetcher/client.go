@@ -0,0 +1,285 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/abhishek622/interviewMin/internal/archive"
+)
+
+// DefaultUserAgent is sent by every fetch unless another one is configured
+const DefaultUserAgent = "Mozilla/5.0 (compatible; interviewMin/1.0)"
+
+// ErrBodyTooLarge is returned while reading a response larger than the configured cap
+var ErrBodyTooLarge = errors.New("response body too large")
+
+// ClientOptions configures the HTTP client shared by all fetchers
+type ClientOptions struct {
+	UserAgent      string
+	ConnectTimeout time.Duration // dial and TLS handshake
+	ReadTimeout    time.Duration // wait for the response headers
+	RequestTimeout time.Duration // a whole attempt, including reading the body
+	MaxBodyBytes   int64
+	MaxRetries     int // extra attempts after a 429 or 5xx response
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration // longer Retry-After values are not waited for
+	MaxRedirects   int
+	// AllowPrivateNetworks turns off the guard against internal addresses, for tests
+	// against a local stand-in server only
+	AllowPrivateNetworks bool
+	// Cache keeps successful responses for CacheTTL, nil turns caching off
+	Cache    archive.Store
+	CacheTTL time.Duration
+}
+
+// DefaultClientOptions are used until Configure is called
+var DefaultClientOptions = ClientOptions{
+	UserAgent:      DefaultUserAgent,
+	ConnectTimeout: 5 * time.Second,
+	ReadTimeout:    15 * time.Second,
+	RequestTimeout: 30 * time.Second,
+	MaxBodyBytes:   5 << 20,
+	MaxRetries:     3,
+	RetryBaseDelay: 500 * time.Millisecond,
+	RetryMaxDelay:  30 * time.Second,
+	MaxRedirects:   5,
+}
+
+// Client sends fetcher requests with timeouts, a body size cap and retries
+type Client struct {
+	http *http.Client
+	opts ClientOptions
+}
+
+func NewClient(opts ClientOptions) *Client {
+	if opts.UserAgent == "" {
+		opts.UserAgent = DefaultUserAgent
+	}
+	dialer := &net.Dialer{Timeout: opts.ConnectTimeout, KeepAlive: 30 * time.Second}
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   opts.ConnectTimeout,
+		ResponseHeaderTimeout: opts.ReadTimeout,
+		MaxIdleConnsPerHost:   4,
+		IdleConnTimeout:       90 * time.Second,
+	}
+	if !opts.AllowPrivateNetworks {
+		dialer.Control = guardDial
+		// a proxy would be dialed instead of the destination and hide it from the guard
+		transport.Proxy = nil
+	}
+
+	c := &Client{opts: opts}
+	c.http = &http.Client{Transport: transport, Timeout: opts.RequestTimeout, CheckRedirect: c.checkRedirect}
+	return c
+}
+
+var httpClient = NewClient(DefaultClientOptions)
+
+// Configure replaces the client used by all fetchers, it is meant to be called once at startup
+func Configure(opts ClientOptions) {
+	httpClient = NewClient(opts)
+}
+
+// Do sends req with the configured User-Agent. Only http and https URLs on public
+// addresses are fetched, including across redirects. Successful responses are served
+// from the cache while fresh. 429 and 5xx responses are retried with jittered backoff,
+// or after the Retry-After the server asks for. The returned body fails with
+// ErrBodyTooLarge once more than the configured size has been read.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if err := c.checkURL(req); err != nil {
+		return nil, err
+	}
+	ctx := req.Context()
+	rec := recorderFrom(ctx)
+
+	cacheKey := ""
+	if c.opts.Cache != nil && ctx.Value(noCacheKey{}) == nil {
+		cacheKey = c.cacheKey(req)
+	}
+	if cacheKey != "" {
+		if b, err := c.opts.Cache.Get(ctx, cacheKey); err == nil && time.Since(b.StoredAt) < c.opts.CacheTTL {
+			rec.record(req.URL.String(), b.ContentType, b.Data)
+			return cachedResponse(req, b), nil
+		}
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK || (cacheKey == "" && rec == nil) {
+		return resp, nil
+	}
+
+	// successful responses are read here so they can be cached and recorded
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	contentType := resp.Header.Get("Content-Type")
+	rec.record(resp.Request.URL.String(), contentType, data)
+	if cacheKey != "" {
+		// a failed cache write only costs a refetch later
+		_ = c.opts.Cache.Put(ctx, cacheKey, &archive.Blob{ContentType: contentType, Data: data})
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return resp, nil
+}
+
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				return nil, errors.New("request body cannot be replayed for a retry")
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+		req.Header.Set("User-Agent", c.opts.UserAgent)
+
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if !retryable(resp.StatusCode) || attempt >= c.opts.MaxRetries {
+			return c.limitBody(resp), nil
+		}
+
+		delay, ok := c.retryDelay(resp, attempt)
+		if !ok {
+			return c.limitBody(resp), nil
+		}
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Get is a shorthand for a GET request to rawURL
+func (c *Client) Get(ctx context.Context, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+// cacheKey returns "" for requests whose body cannot be read again
+func (c *Client) cacheKey(req *http.Request) string {
+	var body []byte
+	if req.Body != nil {
+		if req.GetBody == nil {
+			return ""
+		}
+		rc, err := req.GetBody()
+		if err != nil {
+			return ""
+		}
+		body, err = io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return ""
+		}
+	}
+	return archive.CacheKey(req.Method, req.URL.String(), body)
+}
+
+func cachedResponse(req *http.Request, b *archive.Blob) *http.Response {
+	header := http.Header{}
+	if b.ContentType != "" {
+		header.Set("Content-Type", b.ContentType)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(b.Data)),
+		ContentLength: int64(len(b.Data)),
+		Request:       req,
+	}
+}
+
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// retryDelay honours Retry-After and otherwise backs off exponentially with jitter.
+// It reports false when the server asks for a longer wait than RetryMaxDelay.
+func (c *Client) retryDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	if after := resp.Header.Get("Retry-After"); after != "" {
+		var delay time.Duration
+		if secs, err := strconv.Atoi(after); err == nil {
+			delay = time.Duration(secs) * time.Second
+		} else if at, err := http.ParseTime(after); err == nil {
+			delay = time.Until(at)
+		}
+		if delay > c.opts.RetryMaxDelay {
+			return 0, false
+		}
+		if delay > 0 {
+			return delay, true
+		}
+	}
+
+	backoff := c.opts.RetryBaseDelay << attempt
+	if backoff <= 0 || backoff > c.opts.RetryMaxDelay {
+		backoff = c.opts.RetryMaxDelay
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+	return backoff/2 + rand.N(backoff/2+1), true
+}
+
+// limitedBody fails reads past its cap instead of silently truncating the response
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64 // cap + 1, reading the extra byte tells the body is too large
+}
+
+func (c *Client) limitBody(resp *http.Response) *http.Response {
+	if c.opts.MaxBodyBytes > 0 {
+		resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: c.opts.MaxBodyBytes + 1}
+	}
+	return resp
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		return 0, ErrBodyTooLarge
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining <= 0 {
+		return n - 1, ErrBodyTooLarge
+	}
+	return n, err
+}
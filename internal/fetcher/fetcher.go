@@ -1,9 +1,13 @@
 package fetcher
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/abhishek622/interviewMin/pkg/model"
 )
@@ -12,9 +16,30 @@ type FetchResult struct {
 	Title   string
 	URL     string
 	Content string
+	// PublishedAt is when the source says the post was published or last updated, if it says
+	PublishedAt *time.Time
+	// Raw is the response the post was read from, kept as the snapshot of an import
+	Raw *RawResponse
 }
 
-func Fetch(rawURL string, source model.Source, userAgent string) (*FetchResult, error) {
+// Hash returns a digest of the fetched title and content, used to tell whether a post changed
+func (r *FetchResult) Hash() string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(r.Title) + "\n\n" + strings.TrimSpace(r.Content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Fetch reads a post from its source, together with the raw response it was read from
+func Fetch(ctx context.Context, rawURL string, source model.Source) (*FetchResult, error) {
+	ctx, rec := withRecorder(ctx)
+	res, err := fetch(ctx, rawURL, source)
+	if err != nil {
+		return nil, err
+	}
+	res.Raw = rec.raw()
+	return res, nil
+}
+
+func fetch(ctx context.Context, rawURL string, source model.Source) (*FetchResult, error) {
 	u, err := url.Parse(rawURL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid url: %w", err)
@@ -28,27 +53,40 @@ func Fetch(rawURL string, source model.Source, userAgent string) (*FetchResult,
 			return nil, err
 		}
 		// Passing a dummy CSRF token.
-		res, err := GetLeetcodePost(topicID, userAgent, "jcGYFOTSHkll4nJtvZIa2Wg0YGiHfT")
+		res, err := GetLeetcodePost(ctx, topicID, "jcGYFOTSHkll4nJtvZIa2Wg0YGiHfT")
 		if err != nil {
 			return nil, err
 		}
-		return &FetchResult{Title: res.Title, URL: res.URL, Content: res.Content}, nil
+		return &FetchResult{Title: res.Title, URL: res.URL, Content: res.Content, PublishedAt: res.CreatedAt}, nil
 	} else if strings.Contains(host, "reddit.com") && source == model.SourceReddit {
-		res, err := GetRedditPost(rawURL, userAgent)
+		res, err := GetRedditPost(ctx, rawURL)
 		if err != nil {
 			return nil, err
 		}
-		return &FetchResult{Title: res.Title, URL: res.URL, Content: res.Content}, nil
+		return &FetchResult{Title: res.Title, URL: res.URL, Content: res.Content, PublishedAt: res.CreatedAt}, nil
 	} else if strings.Contains(host, "geeksforgeeks.org") && source == model.SourceGFG {
 		cleanURL, err := ParseGeeksforgeeksURL(rawURL)
 		if err != nil {
 			return nil, err
 		}
-		res, err := GetGfGPost(cleanURL, userAgent)
+		res, err := GetGfGPost(ctx, cleanURL)
+		if err != nil {
+			return nil, err
+		}
+		return &FetchResult{Title: res.Title, URL: res.URL, Content: res.Content, PublishedAt: ParseGfGDate(res.LastUpdated)}, nil
+	} else if source == model.SourceOther {
+		if rule := MatchScrapeRule(u); rule != nil {
+			res, err := GetWithRule(ctx, rule, rawURL)
+			if err != nil {
+				return nil, err
+			}
+			return &FetchResult{Title: res.Title, URL: res.URL, Content: res.Content, PublishedAt: res.PublishedAt}, nil
+		}
+		res, err := GetArticle(ctx, rawURL)
 		if err != nil {
 			return nil, err
 		}
-		return &FetchResult{Title: res.Title, URL: res.URL, Content: res.Content}, nil
+		return &FetchResult{Title: res.Title, URL: res.URL, Content: res.Content, PublishedAt: res.PublishedAt}, nil
 	}
 
 	return nil, fmt.Errorf("unsupported domain: %s", host)
@@ -1,12 +1,14 @@
 package fetcher
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 )
@@ -18,20 +20,22 @@ type GfGPostResponse struct {
 	LastUpdated string
 }
 
-func GetGfGPost(pageURL, userAgent string) (GfGPostResponse, error) {
-	req, err := http.NewRequest("GET", pageURL, nil)
+func GetGfGPost(ctx context.Context, pageURL string) (GfGPostResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
 	if err != nil {
 		return GfGPostResponse{}, err
 	}
-	req.Header.Set("User-Agent", userAgent)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return GfGPostResponse{}, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return GfGPostResponse{}, fmt.Errorf("unexpected status %d from geeksforgeeks", resp.StatusCode)
+	}
+
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
 		return GfGPostResponse{}, err
@@ -78,6 +82,21 @@ func GetGfGPost(pageURL, userAgent string) (GfGPostResponse, error) {
 	}, nil
 }
 
+// ParseGfGDate reads the "Last Updated" text of a GfG article, it returns nil when the
+// text is not a date
+func ParseGfGDate(text string) *time.Time {
+	text = strings.TrimSpace(text)
+	if i := strings.LastIndex(text, ":"); i >= 0 {
+		text = strings.TrimSpace(text[i+1:])
+	}
+	for _, layout := range []string{"02 Jan, 2006", "2 Jan, 2006", "02 Jan 2006", "January 2, 2006", "Jan 2, 2006", "2006-01-02"} {
+		if t, err := time.Parse(layout, text); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
 // processElement handles different HTML elements and formats them appropriately
 func processElement(s *goquery.Selection, builder *strings.Builder) {
 	nodeName := goquery.NodeName(s)
@@ -0,0 +1,85 @@
+package fetcher
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+)
+
+var (
+	// ErrBlockedAddress is returned when a fetch would connect to a private or internal address
+	ErrBlockedAddress = errors.New("destination address is not allowed")
+	// ErrUnsupportedScheme is returned for URLs that are not http or https
+	ErrUnsupportedScheme = errors.New("url scheme must be http or https")
+	// ErrTooManyRedirects is returned when a fetch is redirected more than the configured count
+	ErrTooManyRedirects = errors.New("too many redirects")
+)
+
+// blockedPrefixes are ranges not covered by the net/netip helpers used in allowedAddr
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier grade NAT, also used by cloud metadata services
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),  // documentation
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),  // reserved, includes broadcast
+	netip.MustParsePrefix("64:ff9b::/96"), // NAT64, can embed any IPv4 address
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// allowedAddr tells whether addr is a public unicast address
+func allowedAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	for _, p := range blockedPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// guardDial runs for every connection after DNS resolution, so a public name that
+// resolves to an internal address is refused as well
+func guardDial(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !allowedAddr(addr) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+	return nil
+}
+
+// checkURL refuses schemes other than http and https, and hosts that are literal internal addresses
+func (c *Client) checkURL(req *http.Request) error {
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return fmt.Errorf("%w, got %q", ErrUnsupportedScheme, req.URL.Scheme)
+	}
+	if c.opts.AllowPrivateNetworks {
+		return nil
+	}
+	if addr, err := netip.ParseAddr(req.URL.Hostname()); err == nil && !allowedAddr(addr) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, addr)
+	}
+	return nil
+}
+
+// checkRedirect re-checks every redirect hop before it is followed
+func (c *Client) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) > c.opts.MaxRedirects {
+		return fmt.Errorf("%w: stopped after %d", ErrTooManyRedirects, c.opts.MaxRedirects)
+	}
+	return c.checkURL(req)
+}
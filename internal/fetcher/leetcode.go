@@ -2,6 +2,7 @@ package fetcher
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,9 +16,10 @@ import (
 
 // --- Public response (only the 3 fields you asked for) ---
 type LeetcodePostResponse struct {
-	Title   string `json:"title"`
-	URL     string `json:"url"`
-	Content string `json:"content"`
+	Title     string     `json:"title"`
+	URL       string     `json:"url"`
+	Content   string     `json:"content"`
+	CreatedAt *time.Time `json:"created_at"`
 }
 
 type GraphQLRequest struct {
@@ -32,6 +34,7 @@ type discussionResponse struct {
 			Title     string `json:"title"`
 			Permalink string `json:"slug"` // slug is not a full permalink; we will form canonical url from returned data if available
 			Content   string `json:"content"`
+			CreatedAt string `json:"createdAt"`
 			// Note: API returns `ugcArticleDiscussionArticle`, map appropriately in the outer field tag below
 		} `json:"ugcArticleDiscussionArticle"`
 	} `json:"data"`
@@ -59,7 +62,7 @@ func ParseLeetcodeDiscussURL(raw string) (topicID string, err error) {
 	return m[1], nil
 }
 
-func GetLeetcodePost(topicID, userAgent, csrfToken string) (*LeetcodePostResponse, error) {
+func GetLeetcodePost(ctx context.Context, topicID, csrfToken string) (*LeetcodePostResponse, error) {
 	graphqlURL := "https://leetcode.com/graphql/"
 
 	graphqlBody := GraphQLRequest{
@@ -71,6 +74,7 @@ func GetLeetcodePost(topicID, userAgent, csrfToken string) (*LeetcodePostRespons
     summary
     content
     articleType
+    createdAt
   }
 }
     `,
@@ -83,18 +87,16 @@ func GetLeetcodePost(topicID, userAgent, csrfToken string) (*LeetcodePostRespons
 		return nil, fmt.Errorf("failed to marshal graphql body: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", graphqlURL, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", graphqlURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, err
 	}
 
 	// Required headers
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", userAgent)
 	req.Header.Set("Cookie", "csrftoken="+csrfToken)
 
-	client := &http.Client{Timeout: 15 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request error: %w", err)
 	}
@@ -121,9 +123,13 @@ func GetLeetcodePost(topicID, userAgent, csrfToken string) (*LeetcodePostRespons
 		canonicalURL = fmt.Sprintf("https://leetcode.com/discuss/post/%s/%s", topicID, strings.Trim(article.Permalink, "/"))
 	}
 
-	return &LeetcodePostResponse{
+	res := &LeetcodePostResponse{
 		Title:   strings.TrimSpace(article.Title),
 		URL:     canonicalURL,
 		Content: strings.TrimSpace(article.Content),
-	}, nil
+	}
+	if createdAt, err := time.Parse(time.RFC3339, article.CreatedAt); err == nil {
+		res.CreatedAt = &createdAt
+	}
+	return res, nil
 }
@@ -0,0 +1,171 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	discussPageSize = 50
+	// discussMaxPages bounds a crawl when the date range reaches far back
+	discussMaxPages = 20
+)
+
+// DiscussListOptions selects discuss posts by company tag and creation date
+type DiscussListOptions struct {
+	CompanyTag string
+	// Since and Until bound the creation date, zero values leave that side open
+	Since time.Time
+	Until time.Time
+	// MaxPosts stops the crawl early, 0 means no limit
+	MaxPosts int
+}
+
+// DiscussPost is a discuss post listed by the crawler
+type DiscussPost struct {
+	TopicID   string
+	Title     string
+	URL       string
+	CreatedAt time.Time
+}
+
+type discussListResponse struct {
+	Data struct {
+		Articles struct {
+			PageInfo struct {
+				HasNextPage bool `json:"hasNextPage"`
+			} `json:"pageInfo"`
+			Edges []struct {
+				Node struct {
+					TopicID   json.Number `json:"topicId"`
+					Title     string      `json:"title"`
+					Slug      string      `json:"slug"`
+					CreatedAt string      `json:"createdAt"`
+				} `json:"node"`
+			} `json:"edges"`
+		} `json:"ugcArticleDiscussionArticles"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// ListLeetcodeDiscussPosts lists interview posts tagged with a company, newest first,
+// paging until the posts are older than opts.Since
+func ListLeetcodeDiscussPosts(ctx context.Context, opts DiscussListOptions) ([]DiscussPost, error) {
+	tag := strings.ToLower(strings.TrimSpace(opts.CompanyTag))
+	if tag == "" {
+		return nil, fmt.Errorf("company tag is required")
+	}
+
+	var out []DiscussPost
+	for page := 0; page < discussMaxPages; page++ {
+		res, err := listDiscussPage(ctx, tag, page*discussPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		reachedSince := false
+		for _, edge := range res.Data.Articles.Edges {
+			node := edge.Node
+			createdAt, err := time.Parse(time.RFC3339, node.CreatedAt)
+			if err != nil {
+				continue
+			}
+			if !opts.Since.IsZero() && createdAt.Before(opts.Since) {
+				reachedSince = true
+				break
+			}
+			if !opts.Until.IsZero() && createdAt.After(opts.Until) {
+				continue
+			}
+
+			url := fmt.Sprintf("https://leetcode.com/discuss/post/%s/", node.TopicID)
+			if node.Slug != "" {
+				url = fmt.Sprintf("https://leetcode.com/discuss/post/%s/%s/", node.TopicID, strings.Trim(node.Slug, "/"))
+			}
+			out = append(out, DiscussPost{
+				TopicID:   node.TopicID.String(),
+				Title:     strings.TrimSpace(node.Title),
+				URL:       url,
+				CreatedAt: createdAt,
+			})
+			if opts.MaxPosts > 0 && len(out) >= opts.MaxPosts {
+				return out, nil
+			}
+		}
+
+		if reachedSince || !res.Data.Articles.PageInfo.HasNextPage {
+			break
+		}
+	}
+	return out, nil
+}
+
+func listDiscussPage(ctx context.Context, tag string, skip int) (*discussListResponse, error) {
+	graphqlBody := GraphQLRequest{
+		Query: `
+    query discussPostItems($orderBy: ArticleOrderByEnum, $keywords: [String]!, $tagSlugs: [String!], $skip: Int, $first: Int) {
+  ugcArticleDiscussionArticles(orderBy: $orderBy, keywords: $keywords, tagSlugs: $tagSlugs, skip: $skip, first: $first) {
+    pageInfo {
+      hasNextPage
+    }
+    edges {
+      node {
+        topicId
+        title
+        slug
+        createdAt
+      }
+    }
+  }
+}
+    `,
+		Variables: map[string]interface{}{
+			"orderBy":  "MOST_RECENT",
+			"keywords": []string{""},
+			"tagSlugs": []string{"interview", tag},
+			"skip":     skip,
+			"first":    discussPageSize,
+		},
+		OperationName: "discussPostItems",
+	}
+
+	jsonData, err := json.Marshal(graphqlBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal graphql body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://leetcode.com/graphql/", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Referer", "https://leetcode.com/discuss/")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return nil, fmt.Errorf("unexpected status %d from leetcode: %s", resp.StatusCode, string(body))
+	}
+
+	var res discussListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("failed to decode leetcode response: %w", err)
+	}
+	if len(res.Errors) > 0 {
+		return nil, fmt.Errorf("leetcode graphql error: %s", res.Errors[0].Message)
+	}
+	return &res, nil
+}
@@ -0,0 +1,111 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type LeetcodeProblem struct {
+	ID         int      `json:"id"`
+	Title      string   `json:"title"`
+	Slug       string   `json:"slug"`
+	Difficulty string   `json:"difficulty"`
+	Topics     []string `json:"topics"`
+}
+
+type problemListResponse struct {
+	Data struct {
+		ProblemsetQuestionList struct {
+			Total     int `json:"total"`
+			Questions []struct {
+				FrontendQuestionID string `json:"frontendQuestionId"`
+				Title              string `json:"title"`
+				TitleSlug          string `json:"titleSlug"`
+				Difficulty         string `json:"difficulty"`
+				TopicTags          []struct {
+					Slug string `json:"slug"`
+				} `json:"topicTags"`
+			} `json:"questions"`
+		} `json:"problemsetQuestionList"`
+	} `json:"data"`
+}
+
+// GetLeetcodeProblems fetches one page of the LeetCode problem list and the total number of problems
+func GetLeetcodeProblems(ctx context.Context, skip, limit int) ([]LeetcodeProblem, int, error) {
+	graphqlURL := "https://leetcode.com/graphql/"
+
+	graphqlBody := GraphQLRequest{
+		Query: `
+    query problemsetQuestionList($categorySlug: String, $limit: Int, $skip: Int, $filters: QuestionListFilterInput) {
+  problemsetQuestionList: questionList(categorySlug: $categorySlug, limit: $limit, skip: $skip, filters: $filters) {
+    total: totalNum
+    questions: data {
+      frontendQuestionId: questionFrontendId
+      title
+      titleSlug
+      difficulty
+      topicTags {
+        slug
+      }
+    }
+  }
+}
+    `,
+		Variables:     map[string]interface{}{"categorySlug": "", "skip": skip, "limit": limit, "filters": map[string]interface{}{}},
+		OperationName: "problemsetQuestionList",
+	}
+
+	jsonData, err := json.Marshal(graphqlBody)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to marshal graphql body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", graphqlURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return nil, 0, fmt.Errorf("unexpected status %d from leetcode: %s", resp.StatusCode, string(body))
+	}
+
+	var apiResp problemListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode leetcode problem list: %w", err)
+	}
+
+	list := apiResp.Data.ProblemsetQuestionList
+	out := make([]LeetcodeProblem, 0, len(list.Questions))
+	for _, q := range list.Questions {
+		id, err := strconv.Atoi(q.FrontendQuestionID)
+		if err != nil {
+			continue
+		}
+		topics := make([]string, 0, len(q.TopicTags))
+		for _, t := range q.TopicTags {
+			topics = append(topics, t.Slug)
+		}
+		out = append(out, LeetcodeProblem{
+			ID:         id,
+			Title:      q.Title,
+			Slug:       q.TitleSlug,
+			Difficulty: q.Difficulty,
+			Topics:     topics,
+		})
+	}
+
+	return out, list.Total, nil
+}
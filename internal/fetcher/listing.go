@@ -0,0 +1,131 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/abhishek622/interviewMin/pkg/model"
+)
+
+// ListedPost is a post found on a source listing, before its content is fetched
+type ListedPost struct {
+	Source    model.Source
+	URL       string
+	Title     string
+	CreatedAt *time.Time
+}
+
+// ListSubredditPosts searches a subreddit for new posts matching query, newest first
+func ListSubredditPosts(ctx context.Context, subreddit, query string, since time.Time) ([]ListedPost, error) {
+	subreddit = strings.TrimPrefix(strings.TrimSpace(subreddit), "r/")
+	if subreddit == "" {
+		return nil, fmt.Errorf("subreddit is required")
+	}
+	searchURL := fmt.Sprintf("https://www.reddit.com/r/%s/search.json?q=%s&restrict_sr=1&sort=new&limit=100&raw_json=1",
+		url.PathEscape(subreddit), url.QueryEscape(query))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("unexpected status %d from reddit: %s", resp.StatusCode, string(body))
+	}
+
+	var listing Listing
+	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
+		return nil, fmt.Errorf("failed to decode reddit search: %w", err)
+	}
+
+	var out []ListedPost
+	for _, child := range listing.Data.Children {
+		var post redditPost
+		if child.Kind != "t3" || json.Unmarshal(child.Data, &post) != nil {
+			continue
+		}
+		createdAt := time.Unix(int64(post.Created), 0).UTC()
+		if !since.IsZero() && createdAt.Before(since) {
+			continue
+		}
+		if redditRemoved(post) != nil {
+			continue
+		}
+		out = append(out, ListedPost{
+			Source:    model.SourceReddit,
+			URL:       "https://www.reddit.com" + post.Permalink,
+			Title:     strings.TrimSpace(post.Title),
+			CreatedAt: &createdAt,
+		})
+	}
+	return out, nil
+}
+
+// ListGfGPosts collects the interview experience links of a GfG listing page, such as a
+// company tag page. GfG listings carry no dates, already seen posts are left to the caller.
+func ListGfGPosts(ctx context.Context, listingURL string) ([]ListedPost, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", listingURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from geeksforgeeks", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var out []ListedPost
+	doc.Find(`a[href*="/interview-experiences/"]`).Each(func(i int, a *goquery.Selection) {
+		href, _ := a.Attr("href")
+		link, err := resp.Request.URL.Parse(href)
+		if err != nil {
+			return
+		}
+		cleanURL, err := ParseGeeksforgeeksURL(link.String())
+		if err != nil || seen[cleanURL] {
+			return
+		}
+		seen[cleanURL] = true
+		out = append(out, ListedPost{
+			Source: model.SourceGFG,
+			URL:    cleanURL,
+			Title:  cleanInlineText(a.Text()),
+		})
+	})
+	return out, nil
+}
+
+// ListedLeetcodePosts converts crawled discuss posts to listed posts
+func ListedLeetcodePosts(posts []DiscussPost) []ListedPost {
+	out := make([]ListedPost, len(posts))
+	for i, p := range posts {
+		createdAt := p.CreatedAt
+		out[i] = ListedPost{Source: model.SourceLeetcode, URL: p.URL, Title: p.Title, CreatedAt: &createdAt}
+	}
+	return out
+}
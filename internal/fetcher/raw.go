@@ -0,0 +1,54 @@
+package fetcher
+
+import (
+	"context"
+	"sync"
+)
+
+// RawResponse is the unparsed body a post was extracted from
+type RawResponse struct {
+	URL         string
+	ContentType string
+	Body        []byte
+}
+
+type noCacheKey struct{}
+
+// WithoutCache makes the fetches made with ctx skip the cache, for when the current
+// version of a post is needed
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+type recorderKey struct{}
+
+// recorder keeps the last successful response of the fetches made with its context
+type recorder struct {
+	mu   sync.Mutex
+	last *RawResponse
+}
+
+func withRecorder(ctx context.Context) (context.Context, *recorder) {
+	rec := &recorder{}
+	return context.WithValue(ctx, recorderKey{}, rec), rec
+}
+
+func recorderFrom(ctx context.Context) *recorder {
+	rec, _ := ctx.Value(recorderKey{}).(*recorder)
+	return rec
+}
+
+func (r *recorder) record(url, contentType string, body []byte) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.last = &RawResponse{URL: url, ContentType: contentType, Body: body}
+}
+
+func (r *recorder) raw() *RawResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
@@ -1,53 +1,244 @@
 package fetcher
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// redditAuthorComments is how many comments by the original poster are included
+	redditAuthorComments = 10
+	// redditTopComments is how many of the highest scored other comments are included
+	redditTopComments = 10
+	// redditMinCommentScore leaves out downvoted noise
+	redditMinCommentScore = 1
+)
+
+var (
+	ErrRedditRemoved  = errors.New("reddit post was removed")
+	ErrRedditDeleted  = errors.New("reddit post was deleted by its author")
+	ErrRedditPrivate  = errors.New("reddit post is in a private or quarantined subreddit")
+	ErrRedditNotFound = errors.New("reddit post not found")
 )
 
 type Listing struct {
 	Data struct {
 		Children []struct {
-			Data struct {
-				Title     string `json:"title"`
-				Permalink string `json:"permalink"`
-				Selftext  string `json:"selftext"`
-			} `json:"data"`
+			Kind string          `json:"kind"`
+			Data json.RawMessage `json:"data"`
 		} `json:"children"`
 	} `json:"data"`
 }
 
+type redditPost struct {
+	Title               string       `json:"title"`
+	Permalink           string       `json:"permalink"`
+	Selftext            string       `json:"selftext"`
+	Author              string       `json:"author"`
+	Created             float64      `json:"created_utc"`
+	RemovedByCategory   *string      `json:"removed_by_category"`
+	CrosspostParentList []redditPost `json:"crosspost_parent_list"`
+}
+
+type redditComment struct {
+	Author        string          `json:"author"`
+	Body          string          `json:"body"`
+	Score         int             `json:"score"`
+	Stickied      bool            `json:"stickied"`
+	Distinguished *string         `json:"distinguished"`
+	Replies       json.RawMessage `json:"replies"` // "" when there are none
+}
+
 type RedditPostResponse struct {
-	Title   string `json:"title"`
-	URL     string `json:"url"`
-	Content string `json:"content"`
+	Title     string     `json:"title"`
+	URL       string     `json:"url"`
+	Content   string     `json:"content"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// GetRedditPost reads a post with the original poster's comments and the top comments of
+// its thread. Crossposts are followed to the original post.
+func GetRedditPost(ctx context.Context, postURL string) (RedditPostResponse, error) {
+	return getRedditPost(ctx, postURL, true)
 }
 
-func GetRedditPost(postURL, userAgent string) (RedditPostResponse, error) {
-	if postURL[len(postURL)-1] != '/' {
-		postURL += "/"
+func getRedditPost(ctx context.Context, postURL string, followCrosspost bool) (RedditPostResponse, error) {
+	u, err := url.Parse(strings.TrimSpace(postURL))
+	if err != nil || u.Host == "" {
+		return RedditPostResponse{}, fmt.Errorf("invalid url: %s", postURL)
 	}
-	jsonURL := postURL + ".json"
+	jsonURL := fmt.Sprintf("https://www.reddit.com%s/.json?raw_json=1&sort=top&limit=200", strings.TrimSuffix(u.Path, "/"))
 
-	req, _ := http.NewRequest("GET", jsonURL, nil)
-	req.Header.Set("User-Agent", userAgent)
+	req, err := http.NewRequestWithContext(ctx, "GET", jsonURL, nil)
+	if err != nil {
+		return RedditPostResponse{}, err
+	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return RedditPostResponse{}, err
 	}
 	defer resp.Body.Close()
 
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusForbidden:
+		return RedditPostResponse{}, ErrRedditPrivate
+	case http.StatusNotFound:
+		return RedditPostResponse{}, ErrRedditNotFound
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return RedditPostResponse{}, fmt.Errorf("unexpected status %d from reddit: %s", resp.StatusCode, string(body))
+	}
+
 	var data []Listing
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return RedditPostResponse{}, fmt.Errorf("failed to decode reddit response: %w", err)
+	}
+	if len(data) == 0 || len(data[0].Data.Children) == 0 {
+		return RedditPostResponse{}, ErrRedditNotFound
+	}
+
+	var post redditPost
+	if err := json.Unmarshal(data[0].Data.Children[0].Data, &post); err != nil {
+		return RedditPostResponse{}, fmt.Errorf("failed to decode reddit post: %w", err)
+	}
+
+	if followCrosspost && len(post.CrosspostParentList) > 0 && strings.TrimSpace(post.Selftext) == "" {
+		parent := post.CrosspostParentList[0]
+		if parent.Permalink != "" {
+			return getRedditPost(ctx, "https://www.reddit.com"+parent.Permalink, false)
+		}
+	}
+
+	if err := redditRemoved(post); err != nil {
 		return RedditPostResponse{}, err
 	}
 
-	post := data[0].Data.Children[0].Data
+	var comments []redditComment
+	if len(data) > 1 {
+		comments = flattenComments(data[1])
+	}
+
+	var content strings.Builder
+	content.WriteString(strings.TrimSpace(post.Selftext))
+	writeComments(&content, "Comments by the author:", authorComments(comments, post.Author))
+	writeComments(&content, "Top comments:", topComments(comments, post.Author))
 
-	title := post.Title
-	url := "https://reddit.com" + post.Permalink
-	content := post.Selftext
+	res := RedditPostResponse{
+		Title:   post.Title,
+		URL:     "https://www.reddit.com" + post.Permalink,
+		Content: cleanFinalContent(content.String()),
+	}
+	if post.Created > 0 {
+		createdAt := time.Unix(int64(post.Created), 0).UTC()
+		res.CreatedAt = &createdAt
+	}
+	return res, nil
+}
+
+// redditRemoved tells why a post has no content left, if it was taken down
+func redditRemoved(post redditPost) error {
+	category := ""
+	if post.RemovedByCategory != nil {
+		category = *post.RemovedByCategory
+	}
+	switch {
+	case category == "deleted" || post.Selftext == "[deleted]":
+		return ErrRedditDeleted
+	case category != "" || post.Selftext == "[removed]":
+		return ErrRedditRemoved
+	}
+	return nil
+}
+
+// flattenComments walks a comment listing depth first, skipping "load more" stubs
+func flattenComments(listing Listing) []redditComment {
+	var out []redditComment
+	for _, child := range listing.Data.Children {
+		if child.Kind != "t1" {
+			continue
+		}
+		var c redditComment
+		if err := json.Unmarshal(child.Data, &c); err != nil {
+			continue
+		}
+		out = append(out, c)
+
+		var replies Listing
+		if len(c.Replies) > 0 && c.Replies[0] == '{' && json.Unmarshal(c.Replies, &replies) == nil {
+			out = append(out, flattenComments(replies)...)
+		}
+	}
+	return out
+}
+
+func usableComment(c redditComment) bool {
+	body := strings.TrimSpace(c.Body)
+	if body == "" || body == "[deleted]" || body == "[removed]" {
+		return false
+	}
+	return !c.Stickied && (c.Distinguished == nil || *c.Distinguished != "moderator")
+}
+
+// authorComments returns the original poster's comments in thread order, where follow up
+// questions are often answered
+func authorComments(comments []redditComment, author string) []redditComment {
+	if author == "" || author == "[deleted]" {
+		return nil
+	}
+	var out []redditComment
+	for _, c := range comments {
+		if c.Author == author && usableComment(c) {
+			out = append(out, c)
+			if len(out) == redditAuthorComments {
+				break
+			}
+		}
+	}
+	return out
+}
 
-	return RedditPostResponse{Title: title, URL: url, Content: content}, nil
+// topComments returns the highest scored comments by other users
+func topComments(comments []redditComment, author string) []redditComment {
+	var out []redditComment
+	for _, c := range comments {
+		if c.Author != author && c.Score >= redditMinCommentScore && usableComment(c) {
+			out = append(out, c)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	if len(out) > redditTopComments {
+		out = out[:redditTopComments]
+	}
+	return out
+}
+
+func writeComments(builder *strings.Builder, heading string, comments []redditComment) {
+	if len(comments) == 0 {
+		return
+	}
+	builder.WriteString("\n\n")
+	builder.WriteString(heading)
+	builder.WriteString("\n")
+	for _, c := range comments {
+		// keep the comment's own line breaks, questions are often listed one per line
+		var lines []string
+		for _, line := range strings.Split(c.Body, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		builder.WriteString(" - ")
+		builder.WriteString(strings.Join(lines, "\n   "))
+		builder.WriteString("\n")
+	}
 }
@@ -0,0 +1,212 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/andybalholm/cascadia"
+	"github.com/goccy/go-yaml"
+)
+
+// Rule is a compiled scraping rule
+type Rule struct {
+	model.ScrapeRule
+	path *regexp.Regexp
+}
+
+// CompileRule checks the patterns and selectors of a rule
+func CompileRule(r model.ScrapeRule) (*Rule, error) {
+	r.HostPattern = strings.ToLower(strings.TrimSpace(r.HostPattern))
+	host := strings.TrimPrefix(r.HostPattern, "*.")
+	if host == "" || strings.ContainsAny(host, "*/:") {
+		return nil, fmt.Errorf("rule %q: host_pattern must be a host name, optionally starting with *.", r.Name)
+	}
+	if strings.TrimSpace(r.ContentSelector) == "" {
+		return nil, fmt.Errorf("rule %q: content_selector is required", r.Name)
+	}
+
+	compiled := &Rule{ScrapeRule: r}
+	if r.PathPattern != "" {
+		re, err := regexp.Compile(r.PathPattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: invalid path_pattern: %w", r.Name, err)
+		}
+		compiled.path = re
+	}
+
+	selectors := append([]string{r.TitleSelector, r.ContentSelector, r.DateSelector}, r.RemoveSelectors...)
+	for _, sel := range selectors {
+		if strings.TrimSpace(sel) == "" {
+			continue
+		}
+		if _, err := cascadia.ParseGroup(sel); err != nil {
+			return nil, fmt.Errorf("rule %q: invalid selector %q: %w", r.Name, sel, err)
+		}
+	}
+	return compiled, nil
+}
+
+// Matches tells whether the rule applies to u
+func (r *Rule) Matches(u *url.URL) bool {
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if suffix, ok := strings.CutPrefix(r.HostPattern, "*."); ok {
+		if host != suffix && !strings.HasSuffix(host, "."+suffix) {
+			return false
+		}
+	} else if host != strings.TrimPrefix(r.HostPattern, "www.") {
+		return false
+	}
+	return r.path == nil || r.path.MatchString(u.Path)
+}
+
+// Extract reads a post from a page with the rule's selectors. The content has the same
+// format as the GfG fetcher.
+func (r *Rule) Extract(doc *goquery.Document) (ArticleResponse, error) {
+	doc.Find("script, style, noscript").Remove()
+	for _, sel := range r.RemoveSelectors {
+		if strings.TrimSpace(sel) != "" {
+			doc.Find(sel).Remove()
+		}
+	}
+
+	res := ArticleResponse{Title: articleTitle(doc), PublishedAt: articlePublishedAt(doc)}
+	if r.TitleSelector != "" {
+		if title := cleanInlineText(doc.Find(r.TitleSelector).First().Text()); title != "" {
+			res.Title = title
+		}
+	}
+	if r.DateSelector != "" {
+		if publishedAt := selectionDate(doc.Find(r.DateSelector).First()); publishedAt != nil {
+			res.PublishedAt = publishedAt
+		}
+	}
+
+	content := doc.Find(r.ContentSelector)
+	if content.Length() == 0 {
+		return ArticleResponse{}, fmt.Errorf("content_selector %q matched nothing", r.ContentSelector)
+	}
+	var builder strings.Builder
+	content.Each(func(i int, s *goquery.Selection) {
+		s.Find("h1, h2, h3, h4, h5, h6, p, ul, ol, pre").Each(func(i int, el *goquery.Selection) {
+			processElement(el, &builder)
+		})
+	})
+	res.Content = cleanFinalContent(builder.String())
+	if res.Content == "" {
+		// plain text containers without block elements
+		res.Content = cleanFinalContent(content.Text())
+	}
+	if res.Content == "" {
+		return ArticleResponse{}, errors.New("no content found on page")
+	}
+	return res, nil
+}
+
+// selectionDate reads a date from a datetime or content attribute, or from the text
+func selectionDate(s *goquery.Selection) *time.Time {
+	var candidates []string
+	for _, attr := range []string{"datetime", "content"} {
+		if v, ok := s.Attr(attr); ok {
+			candidates = append(candidates, v)
+		}
+	}
+	candidates = append(candidates, s.Text())
+
+	for _, v := range candidates {
+		v = strings.TrimSpace(v)
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05.000Z07:00", "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return &t
+			}
+		}
+		if t := ParseGfGDate(v); t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+// GetWithRule downloads a page and reads its post with rule
+func GetWithRule(ctx context.Context, rule *Rule, pageURL string) (ArticleResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return ArticleResponse{}, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return ArticleResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ArticleResponse{}, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, req.URL.Host)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return ArticleResponse{}, err
+	}
+
+	res, err := rule.Extract(doc)
+	if err != nil {
+		return ArticleResponse{}, err
+	}
+	res.URL = pageURL
+	return res, nil
+}
+
+var (
+	rulesMu sync.RWMutex
+	rules   []*Rule
+)
+
+// SetScrapeRules replaces the rules used for sites without a dedicated fetcher, the
+// first matching rule is used
+func SetScrapeRules(rs []*Rule) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules = rs
+}
+
+// MatchScrapeRule returns the rule for u, nil when no rule matches
+func MatchScrapeRule(u *url.URL) *Rule {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+	for _, r := range rules {
+		if r.Matches(u) {
+			return r
+		}
+	}
+	return nil
+}
+
+// LoadScrapeRulesFile reads rules from a YAML file with a top level "rules" list
+func LoadScrapeRulesFile(path string) ([]model.ScrapeRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read scrape rules file: %w", err)
+	}
+
+	var file struct {
+		Rules []model.ScrapeRule `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse scrape rules file: %w", err)
+	}
+	for i := range file.Rules {
+		file.Rules[i].Enabled = true
+	}
+	return file.Rules, nil
+}
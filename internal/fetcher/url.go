@@ -0,0 +1,77 @@
+package fetcher
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+)
+
+var redditPostPath = regexp.MustCompile(`^/r/([\w-]+)/comments/(\w+)`)
+
+// DetectSource returns the source a URL is fetched from
+func DetectSource(rawURL string) (model.Source, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid url: %s", rawURL)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("url scheme must be http or https, got %s", u.Scheme)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "leetcode.com" || strings.HasSuffix(host, ".leetcode.com"):
+		return model.SourceLeetcode, nil
+	case host == "reddit.com" || strings.HasSuffix(host, ".reddit.com"):
+		return model.SourceReddit, nil
+	case host == "geeksforgeeks.org" || strings.HasSuffix(host, ".geeksforgeeks.org"):
+		return model.SourceGFG, nil
+	}
+	// any other page goes through the generic article fetcher
+	return model.SourceOther, nil
+}
+
+// CanonicalURL returns the form of a post URL used to recognise the same post, whatever
+// tracking parameters, slug or host alias it was shared with
+func CanonicalURL(rawURL string, source model.Source) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	switch source {
+	case model.SourceLeetcode:
+		topicID, err := ParseLeetcodeDiscussURL(rawURL)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("https://leetcode.com/discuss/post/%s/", topicID), nil
+	case model.SourceGFG:
+		cleanURL, err := ParseGeeksforgeeksURL(rawURL)
+		if err != nil {
+			return "", err
+		}
+		u, _ := url.Parse(cleanURL)
+		return "https://www.geeksforgeeks.org" + u.Path, nil
+	case model.SourceReddit:
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid url: %w", err)
+		}
+		m := redditPostPath.FindStringSubmatch(u.Path)
+		if m == nil {
+			return "", fmt.Errorf("url path is not a reddit post (expected /r/<subreddit>/comments/<id>/...)")
+		}
+		return fmt.Sprintf("https://www.reddit.com/r/%s/comments/%s/", strings.ToLower(m[1]), m[2]), nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid url: %s", rawURL)
+	}
+	u.Scheme = "https"
+	u.Host = strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return u.String(), nil
+}
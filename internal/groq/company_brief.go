@@ -0,0 +1,117 @@
+package groq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/abhishek622/interviewMin/pkg"
+)
+
+// briefPromptBudget caps the interview text sent for a company brief, split across interviews
+const briefPromptBudget = 24000
+
+type BriefInterview struct {
+	Date      time.Time
+	Position  string
+	NoOfRound int
+	Location  string
+	Content   string
+	Questions []string
+}
+
+type CompanyBrief struct {
+	Summary        string `json:"summary"`
+	RoundStructure []struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	} `json:"round_structure"`
+	CommonTopics []struct {
+		Topic    string `json:"topic"`
+		Mentions int    `json:"mentions"`
+	} `json:"common_topics"`
+	DifficultyTrend  string   `json:"difficulty_trend"`
+	BehavioralThemes []string `json:"behavioral_themes"`
+	Tips             []string `json:"tips"`
+}
+
+// CompanyBrief summarizes every interview of a company into a preparation brief
+func (c *Client) CompanyBrief(ctx context.Context, company string, interviews []BriefInterview) (*CompanyBrief, error) {
+	systemMsg := `You are an interview coach preparing a candidate for interviews at a specific company.
+
+You receive every interview experience recorded for the company, oldest first, with the questions extracted from each.
+Write a preparation brief based ONLY on these experiences. Do not invent facts about the company.
+
+### RULES
+1. Output ONLY valid JSON. No explanation, no markdown, no backticks.
+2. "round_structure": the typical rounds in order, each with a short description of what happens in it.
+3. "common_topics": the most frequent technical topics (e.g. "graphs", "dynamic programming", "caching"),
+   most frequent first, with the number of interviews mentioning each. At most 10.
+4. "difficulty_trend": one or two sentences on overall difficulty and whether it changed over time.
+5. "behavioral_themes": recurring behavioral / culture themes. Empty array if none.
+6. "tips": concrete, actionable tips taken from the experiences. At most 8.
+7. "summary": three or four sentences a candidate should read first.
+
+### Output Schema
+{
+  "summary": "string",
+  "round_structure": [{ "name": "string", "description": "string" }],
+  "common_topics": [{ "topic": "string", "mentions": 0 }],
+  "difficulty_trend": "string",
+  "behavioral_themes": ["string"],
+  "tips": ["string"]
+}
+`
+
+	perInterview := briefPromptBudget / max(len(interviews), 1)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Company: %s\nInterviews: %d\n", company, len(interviews))
+	for i, iv := range interviews {
+		fmt.Fprintf(&b, "\n### Interview %d (%s)\n", i+1, iv.Date.Format("2006-01-02"))
+		if iv.Position != "" {
+			fmt.Fprintf(&b, "Position: %s\n", iv.Position)
+		}
+		if iv.NoOfRound > 0 {
+			fmt.Fprintf(&b, "Rounds: %d\n", iv.NoOfRound)
+		}
+		if iv.Location != "" {
+			fmt.Fprintf(&b, "Location: %s\n", iv.Location)
+		}
+		if len(iv.Questions) > 0 {
+			b.WriteString("Questions:\n")
+			for _, q := range iv.Questions {
+				fmt.Fprintf(&b, "- %s\n", q)
+			}
+		}
+
+		content := pkg.Truncate(iv.Content, perInterview)
+		fmt.Fprintf(&b, "Experience:\n%s\n", content)
+	}
+
+	// the questions are sent in full on top of the experience budget, cap the total anyway
+	userPrompt := pkg.Truncate(b.String(), briefPromptBudget*2)
+
+	chatReq := ChatRequest{
+		Messages: []map[string]string{
+			{"role": "system", "content": systemMsg},
+			{"role": "user", "content": userPrompt},
+		},
+		MaxTokens:   2000,
+		Temperature: 0.2,
+	}
+
+	respStr, err := c.Chat(ctx, chatReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var brief CompanyBrief
+	if err := json.Unmarshal([]byte(respStr), &brief); err != nil {
+		return nil, fmt.Errorf("failed to parse ai company brief: %w", err)
+	}
+
+	return &brief, nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+
+	"github.com/abhishek622/interviewMin/pkg"
 )
 
 type ExtractedData struct {
@@ -16,10 +19,13 @@ type ExtractedData struct {
 		Question string `json:"question"`
 		Type     string `json:"type"`
 	} `json:"questions"`
-	FullExperience string `json:"full_experience"`
+	FullExperience string   `json:"full_experience"`
+	Tags           []string `json:"tags"`
 }
 
-func (c *Client) ExtractInterview(ctx context.Context, content string) (*ExtractedData, error) {
+// ExtractInterview extracts structured interview data from raw text. When tagVocabulary
+// is non-empty the model is also asked to suggest matching tags from it.
+func (c *Client) ExtractInterview(ctx context.Context, content string, tagVocabulary []string) (*ExtractedData, error) {
 	systemMsg := `
 You are an advanced Interview Experience Extraction Engine.
 
@@ -88,6 +94,17 @@ Rules:
 Think step-by-step, but RETURN ONLY THE FINAL JSON.
 `
 
+	if len(tagVocabulary) > 0 {
+		systemMsg += fmt.Sprintf(`
+### Tag Suggestions
+Also include a "tags" field: an array of tags that describe this experience.
+Pick ONLY from the following existing tags, copied exactly. Never create new tags.
+If none apply, return "tags": []
+
+Existing tags: %s
+`, strings.Join(tagVocabulary, ", "))
+	}
+
 	userPrompt := fmt.Sprintf(`
 Read the following raw interview text and extract the structured data exactly
 as per the schema described. Remember to follow all rules strictly.
@@ -96,9 +113,7 @@ TEXT START:
 %s
 TEXT END
 `, content)
-	if len(userPrompt) > 10000 {
-		userPrompt = userPrompt[:10000]
-	}
+	userPrompt = pkg.Truncate(userPrompt, 10000)
 
 	chatReq := ChatRequest{
 		Messages:    []map[string]string{{"role": "system", "content": systemMsg}, {"role": "user", "content": userPrompt}},
@@ -116,5 +131,26 @@ TEXT END
 		return nil, fmt.Errorf("failed to parse ai response: %w", err)
 	}
 
+	extracted.Tags = filterVocabulary(extracted.Tags, tagVocabulary)
+
 	return &extracted, nil
 }
+
+// filterVocabulary drops any suggested tag that is not part of the vocabulary
+func filterVocabulary(suggested, vocabulary []string) []string {
+	known := make(map[string]string, len(vocabulary))
+	for _, v := range vocabulary {
+		known[strings.ToLower(strings.TrimSpace(v))] = v
+	}
+
+	out := []string{}
+	seen := make(map[string]bool)
+	for _, s := range suggested {
+		key := strings.ToLower(strings.TrimSpace(s))
+		if name, ok := known[key]; ok && !seen[key] {
+			seen[key] = true
+			out = append(out, name)
+		}
+	}
+	return out
+}
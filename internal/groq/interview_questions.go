@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+
+	"github.com/abhishek622/interviewMin/pkg"
 )
 
 type ExtractedQuestions struct {
@@ -31,9 +33,7 @@ Rules:
 `
 
 	userPrompt := fmt.Sprintf("Interview experience:\n%s", content)
-	if len(userPrompt) > 10000 {
-		userPrompt = userPrompt[:10000]
-	}
+	userPrompt = pkg.Truncate(userPrompt, 10000)
 
 	chatReq := ChatRequest{
 		Messages: []map[string]string{
@@ -0,0 +1,53 @@
+package groq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type problemChoice struct {
+	Index int `json:"index"`
+}
+
+// MatchProblem asks the model which of the candidate LeetCode titles the question refers to.
+// It returns the index into titles, or -1 when none of them fit.
+func (c *Client) MatchProblem(ctx context.Context, question string, titles []string) (int, error) {
+	systemMsg := `You match interview questions to LeetCode problems.
+You are given a question and a numbered list of candidate LeetCode problem titles.
+Reply with ONLY a JSON object: {"index": <number>}
+- Use the number of the candidate that is the same problem as the question.
+- Use -1 if none of the candidates is clearly the same problem.
+No explanation, no markdown, no backticks.`
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Question:\n%s\n\nCandidates:\n", question)
+	for i, t := range titles {
+		fmt.Fprintf(&b, "%d. %s\n", i, t)
+	}
+
+	chatReq := ChatRequest{
+		Messages: []map[string]string{
+			{"role": "system", "content": systemMsg},
+			{"role": "user", "content": b.String()},
+		},
+		MaxTokens:   50,
+		Temperature: 0.0,
+	}
+
+	respStr, err := c.Chat(ctx, chatReq)
+	if err != nil {
+		return -1, err
+	}
+
+	var choice problemChoice
+	if err := json.Unmarshal([]byte(strings.TrimSpace(respStr)), &choice); err != nil {
+		return -1, fmt.Errorf("failed to parse ai problem match: %w; raw response: %q", err, respStr)
+	}
+	if choice.Index < -1 || choice.Index >= len(titles) {
+		return -1, nil
+	}
+
+	return choice.Index, nil
+}
@@ -0,0 +1,149 @@
+package groq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/abhishek622/interviewMin/pkg"
+)
+
+// MockCandidate is a question offered to the model when planning a mock interview
+type MockCandidate struct {
+	Question   string
+	Type       string
+	TimesAsked int
+}
+
+type MockRound struct {
+	Name      string              `json:"name"`
+	Questions []MockRoundQuestion `json:"questions"`
+}
+
+type MockRoundQuestion struct {
+	Question string `json:"question"`
+	Type     string `json:"type"`
+	// SourceIndex points into the candidate list, -1 for a question the model wrote itself
+	SourceIndex int `json:"source_index"`
+}
+
+type AnswerFeedback struct {
+	Score        int      `json:"score"`
+	Summary      string   `json:"summary"`
+	Strengths    []string `json:"strengths"`
+	Improvements []string `json:"improvements"`
+}
+
+// PlanMockInterview arranges the selected candidates into interview rounds for the company.
+// missing lists question types the company history had nothing for, the model may write one of each.
+func (c *Client) PlanMockInterview(ctx context.Context, company string, candidates []MockCandidate, missing []string) ([]MockRound, error) {
+	systemMsg := `You are an experienced interviewer designing a realistic mock interview loop.
+
+You receive a company name and a numbered list of questions this company asked in past interviews,
+with how many times each was asked. Arrange them into interview rounds the way this company runs its loop.
+
+### RULES
+1. Output ONLY valid JSON. No explanation, no markdown, no backticks.
+2. Use EVERY candidate question exactly once. Keep its wording and set "source_index" to its number.
+3. Group questions into rounds such as "Online Assessment", "DSA Round 1", "System Design", "Hiring Manager / Behavioral".
+   Order rounds the way a real loop would run.
+4. If you are told some question types are missing, you MAY add ONE typical question per missing type
+   with "source_index": -1. Never add anything else.
+5. "type" must be one of "dsa", "system_design", "behavioral".
+
+### Output Schema
+{
+  "rounds": [
+    {
+      "name": "string",
+      "questions": [
+        { "question": "string", "type": "dsa | system_design | behavioral", "source_index": 0 }
+      ]
+    }
+  ]
+}
+`
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Company: %s\n\nCandidates:\n", company)
+	for i, cand := range candidates {
+		fmt.Fprintf(&b, "%d. [%s, asked %d times] %s\n", i, cand.Type, cand.TimesAsked, cand.Question)
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(&b, "\nMissing question types: %s\n", strings.Join(missing, ", "))
+	}
+
+	userPrompt := b.String()
+	userPrompt = pkg.Truncate(userPrompt, 10000)
+
+	chatReq := ChatRequest{
+		Messages: []map[string]string{
+			{"role": "system", "content": systemMsg},
+			{"role": "user", "content": userPrompt},
+		},
+		MaxTokens:   2000,
+		Temperature: 0.2,
+	}
+
+	respStr, err := c.Chat(ctx, chatReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan struct {
+		Rounds []MockRound `json:"rounds"`
+	}
+	if err := json.Unmarshal([]byte(respStr), &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse ai mock interview plan: %w", err)
+	}
+	if len(plan.Rounds) == 0 {
+		return nil, fmt.Errorf("ai mock interview plan has no rounds")
+	}
+
+	return plan.Rounds, nil
+}
+
+// EvaluateAnswer grades a candidate's answer to a mock interview question
+func (c *Client) EvaluateAnswer(ctx context.Context, company, question, questionType, answer string) (*AnswerFeedback, error) {
+	systemMsg := `You are a senior interviewer giving feedback on a mock interview answer.
+
+Grade the answer the way the given company would, for the given question type:
+- "dsa": correctness, complexity analysis, edge cases, code quality.
+- "system_design": requirements, high level design, scaling, trade-offs.
+- "behavioral": structure (STAR), ownership, impact, reflection.
+
+Output ONLY valid JSON, no markdown or backticks:
+{
+  "score": 1-10,
+  "summary": "two or three sentences",
+  "strengths": ["string"],
+  "improvements": ["string"]
+}
+`
+
+	userPrompt := fmt.Sprintf("Company: %s\nQuestion type: %s\n\nQuestion:\n%s\n\nAnswer:\n%s", company, questionType, question, answer)
+	userPrompt = pkg.Truncate(userPrompt, 10000)
+
+	chatReq := ChatRequest{
+		Messages: []map[string]string{
+			{"role": "system", "content": systemMsg},
+			{"role": "user", "content": userPrompt},
+		},
+		MaxTokens:   1000,
+		Temperature: 0.0,
+	}
+
+	respStr, err := c.Chat(ctx, chatReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var feedback AnswerFeedback
+	if err := json.Unmarshal([]byte(respStr), &feedback); err != nil {
+		return nil, fmt.Errorf("failed to parse ai answer feedback: %w", err)
+	}
+	feedback.Score = max(1, min(10, feedback.Score))
+
+	return &feedback, nil
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/abhishek622/interviewMin/internal/groq"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// GetCompanyBrief returns an AI preparation brief summarizing every interview of a company.
+// The brief is cached and only regenerated when the company's interviews change, or when
+// refresh=true is passed.
+func (h *Handler) GetCompanyBrief(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	var q model.CompanyBriefQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	identifier := c.Param("identifier")
+	var (
+		company *model.CompanyDetails
+		err     error
+	)
+	if id, parseErr := uuid.Parse(identifier); parseErr == nil {
+		company, err = h.Repository.CompanyDetails(c.Request.Context(), claims.UserID, id)
+	} else {
+		company, err = h.Repository.GetCompanyBySlug(c.Request.Context(), claims.UserID, identifier)
+	}
+	if err != nil {
+		response.NotFound(c, "company not found")
+		return
+	}
+
+	fingerprint, count, err := h.Repository.CompanyBriefFingerprint(c.Request.Context(), claims.UserID, company.CompanyID)
+	if err != nil {
+		h.Logger.Error("get_company_brief: failed to fingerprint interviews",
+			zap.String("company_id", company.CompanyID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch company brief")
+		return
+	}
+	if count == 0 {
+		response.ValidationError(c, "no processed interviews for this company yet")
+		return
+	}
+
+	cached, err := h.Repository.GetCompanyBrief(c.Request.Context(), company.CompanyID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		h.Logger.Error("get_company_brief: failed to fetch cached brief",
+			zap.String("company_id", company.CompanyID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch company brief")
+		return
+	}
+	if cached != nil {
+		cached.CompanyName = company.Name
+		if cached.Fingerprint == fingerprint && !q.Refresh {
+			response.OK(c, cached)
+			return
+		}
+	}
+
+	brief, err := h.generateCompanyBrief(c.Request.Context(), claims.UserID, company)
+	if err != nil {
+		h.Logger.Error("get_company_brief: failed to generate brief",
+			zap.String("company_id", company.CompanyID.String()),
+			zap.Error(err),
+		)
+		if cached != nil {
+			cached.Stale = true
+			response.OK(c, cached)
+			return
+		}
+		response.InternalError(c, "failed to generate company brief")
+		return
+	}
+	brief.Fingerprint = fingerprint
+
+	if err := h.Repository.SaveCompanyBrief(c.Request.Context(), claims.UserID, brief); err != nil {
+		h.Logger.Error("get_company_brief: failed to save brief",
+			zap.String("company_id", company.CompanyID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to save company brief")
+		return
+	}
+
+	h.Logger.Info("get_company_brief: brief generated",
+		zap.String("company_id", company.CompanyID.String()),
+		zap.Int("interviews", brief.InterviewCount),
+	)
+
+	response.OK(c, brief)
+}
+
+func (h *Handler) generateCompanyBrief(ctx context.Context, userID uuid.UUID, company *model.CompanyDetails) (*model.CompanyBrief, error) {
+	if h.GroqClient == nil {
+		return nil, errors.New("ai client is not configured")
+	}
+
+	interviews, err := h.Repository.ListBriefInterviews(ctx, userID, company.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := make([]groq.BriefInterview, len(interviews))
+	for i, iv := range interviews {
+		bi := groq.BriefInterview{Date: iv.CreatedAt, Content: iv.RawInput}
+		if iv.Position != nil {
+			bi.Position = *iv.Position
+		}
+		if iv.NoOfRound != nil {
+			bi.NoOfRound = *iv.NoOfRound
+		}
+		if iv.Location != nil {
+			bi.Location = *iv.Location
+		}
+		for _, q := range iv.Questions {
+			bi.Questions = append(bi.Questions, "["+q.Type+"] "+q.Question)
+		}
+		input[i] = bi
+	}
+
+	out, err := h.GroqClient.CompanyBrief(ctx, company.Name, input)
+	if err != nil {
+		return nil, err
+	}
+
+	brief := &model.CompanyBrief{
+		CompanyID:      company.CompanyID,
+		CompanyName:    company.Name,
+		InterviewCount: len(interviews),
+		Brief: model.CompanyBriefContent{
+			Summary:          out.Summary,
+			RoundStructure:   []model.BriefRound{},
+			CommonTopics:     []model.BriefTopic{},
+			DifficultyTrend:  out.DifficultyTrend,
+			BehavioralThemes: out.BehavioralThemes,
+			Tips:             out.Tips,
+		},
+	}
+	for _, r := range out.RoundStructure {
+		brief.Brief.RoundStructure = append(brief.Brief.RoundStructure, model.BriefRound{Name: r.Name, Description: r.Description})
+	}
+	for _, t := range out.CommonTopics {
+		brief.Brief.CommonTopics = append(brief.Brief.CommonTopics, model.BriefTopic{Topic: t.Topic, Mentions: t.Mentions})
+	}
+	if brief.Brief.BehavioralThemes == nil {
+		brief.Brief.BehavioralThemes = []string{}
+	}
+	if brief.Brief.Tips == nil {
+		brief.Brief.Tips = []string{}
+	}
+	return brief, nil
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"archive/zip"
+	"fmt"
+	"time"
+
+	"github.com/abhishek622/interviewMin/internal/export"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Export streams a zip archive of all companies, interviews and questions of the current user
+func (h *Handler) Export(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	var q model.ExportQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "invalid query parameters")
+		return
+	}
+	switch q.Format {
+	case model.ExportFormatJSON, model.ExportFormatCSV, model.ExportFormatMarkdown:
+	default:
+		response.BadRequest(c, "format must be one of json, csv, markdown")
+		return
+	}
+
+	archive, err := h.Repository.ExportData(c.Request.Context(), claims.UserID)
+	if err != nil {
+		h.Logger.Error("export: failed to load data",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to export data")
+		return
+	}
+
+	filename := fmt.Sprintf("interviewmin-%s-%s.zip", q.Format, time.Now().UTC().Format("20060102"))
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	// headers are sent with the first write, from here on errors can only be logged
+	zw := zip.NewWriter(c.Writer)
+	if err := export.Write(zw, archive, q.Format); err != nil {
+		h.Logger.Error("export: failed to write archive",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+	}
+	if err := zw.Close(); err != nil {
+		h.Logger.Error("export: failed to finish archive",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+	}
+
+	h.Logger.Info("export: archive written",
+		zap.String("user_id", claims.UserID.String()),
+		zap.String("format", string(q.Format)),
+		zap.Int("interviews", len(archive.Interviews)),
+	)
+}
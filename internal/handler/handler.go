@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"github.com/abhishek622/interviewMin/internal/archive"
 	"github.com/abhishek622/interviewMin/internal/auth"
 	"github.com/abhishek622/interviewMin/internal/config"
+	"github.com/abhishek622/interviewMin/internal/embedding"
 	"github.com/abhishek622/interviewMin/internal/groq"
+	"github.com/abhishek622/interviewMin/internal/leetcode"
+	"github.com/abhishek622/interviewMin/internal/notify"
 	"github.com/abhishek622/interviewMin/internal/repository"
 	"github.com/abhishek622/interviewMin/pkg"
 	"github.com/gin-gonic/gin"
@@ -17,6 +21,10 @@ type Handler struct {
 	TokenMaker *auth.JWTMaker
 	Crypto     *pkg.Crypto
 	GroqClient *groq.Client
+	Problems   *leetcode.Catalog
+	Embedder   embedding.Provider
+	Notifier   notify.Notifier
+	Archive    archive.Store
 	Config     *config.Config
 }
 
@@ -27,6 +35,10 @@ func NewHandler(
 	tokenMaker *auth.JWTMaker,
 	crypto *pkg.Crypto,
 	groqClient *groq.Client,
+	problems *leetcode.Catalog,
+	embedder embedding.Provider,
+	notifier notify.Notifier,
+	archiveStore archive.Store,
 	cfg *config.Config,
 ) *Handler {
 	return &Handler{
@@ -35,6 +47,10 @@ func NewHandler(
 		TokenMaker: tokenMaker,
 		Crypto:     crypto,
 		GroqClient: groqClient,
+		Problems:   problems,
+		Embedder:   embedder,
+		Notifier:   notifier,
+		Archive:    archiveStore,
 		Config:     cfg,
 	}
 }
@@ -0,0 +1,366 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/abhishek622/interviewMin/internal/importer"
+	"github.com/abhishek622/interviewMin/internal/repository"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	maxImportFileSize = 10 << 20
+	// maxImportErrors caps the errors stored on a job, error_count keeps the full count
+	maxImportErrors = 500
+	// importProgressEvery is how many rows are processed between progress updates
+	importProgressEvery = 10
+)
+
+// Import reads a CSV file or a JSON export and imports it in a background job.
+// Rows are validated up front, the job reports per row errors.
+func (h *Handler) Import(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	var req model.ImportReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		response.BadRequest(c, "file is required")
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		response.BadRequest(c, "file must be at most 10MB")
+		return
+	}
+
+	if req.Format == "" {
+		switch strings.ToLower(filepath.Ext(fileHeader.Filename)) {
+		case ".csv":
+			req.Format = model.ImportFormatCSV
+		case ".json", ".zip":
+			req.Format = model.ImportFormatJSON
+		}
+	}
+
+	var mapping map[string]string
+	if req.Mapping != "" {
+		if err := json.Unmarshal([]byte(req.Mapping), &mapping); err != nil {
+			response.BadRequest(c, "mapping must be a JSON object of field to column")
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.BadRequest(c, "failed to read file")
+		return
+	}
+	defer file.Close()
+
+	var (
+		rows      []importer.Row
+		companies []model.ExportCompany
+	)
+	switch req.Format {
+	case model.ImportFormatCSV:
+		rows, err = importer.ParseCSV(io.LimitReader(file, maxImportFileSize), mapping)
+	case model.ImportFormatJSON:
+		var data []byte
+		if data, err = io.ReadAll(io.LimitReader(file, maxImportFileSize)); err == nil {
+			rows, companies, err = importer.ParseJSON(data)
+		}
+	default:
+		response.BadRequest(c, "format must be one of csv, json")
+		return
+	}
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	if len(rows) == 0 {
+		response.BadRequest(c, "file has no rows")
+		return
+	}
+
+	job := &model.ImportJob{
+		UserID:           claims.UserID,
+		Format:           req.Format,
+		Filename:         fileHeader.Filename,
+		Status:           model.ImportJobQueued,
+		DryRun:           req.DryRun,
+		ExtractQuestions: req.ExtractQuestions,
+		TotalRows:        len(rows),
+	}
+	for _, row := range rows {
+		addImportErrors(job, row.Errors...)
+	}
+
+	if err := h.Repository.CreateImportJob(c.Request.Context(), job); err != nil {
+		h.Logger.Error("import: failed to create job",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to create import job")
+		return
+	}
+
+	h.Logger.Info("import: job queued",
+		zap.String("user_id", claims.UserID.String()),
+		zap.Int64("job_id", job.JobID),
+		zap.Int("rows", len(rows)),
+		zap.Bool("dry_run", job.DryRun),
+	)
+
+	response.Accepted(c, job)
+
+	go h.runImport(*job, rows, companies)
+}
+
+// FailInterruptedImports marks jobs, batch items and watch interviews left queued or
+// running by a previous process as failed, their work only ever lived in that process's memory
+func (h *Handler) FailInterruptedImports(ctx context.Context) error {
+	const reason = "import was interrupted by a server restart"
+	failed, err := h.Repository.FailInterruptedImportJobs(ctx, reason)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		h.Logger.Warn("import: failed interrupted jobs", zap.Int64("jobs", failed))
+	}
+	items, err := h.Repository.FailInterruptedImportBatches(ctx, reason)
+	if err != nil {
+		return err
+	}
+	if items > 0 {
+		h.Logger.Warn("import: failed interrupted batch items", zap.Int64("items", items))
+	}
+	queued, err := h.Repository.FailQueuedInterviews(ctx, reason)
+	if err != nil {
+		return err
+	}
+	if queued > 0 {
+		h.Logger.Warn("import: failed interrupted queued interviews", zap.Int64("interviews", queued))
+	}
+	return nil
+}
+
+// runImport creates the listed companies and processes the valid rows of an import job.
+// In a dry run nothing is written, the job only reports which rows would be created.
+func (h *Handler) runImport(job model.ImportJob, rows []importer.Row, companies []model.ExportCompany) {
+	ctx := context.Background()
+	log := h.Logger.With(zap.Int64("job_id", job.JobID), zap.String("user_id", job.UserID.String()))
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("import: job panicked", zap.Any("panic", r))
+			job.Status = model.ImportJobFailed
+			msg := "import stopped unexpectedly"
+			if err := h.Repository.FinishImportJob(ctx, &job, &msg); err != nil {
+				log.Error("import: failed to fail job", zap.Error(err))
+			}
+		}
+	}()
+
+	job.Status = model.ImportJobRunning
+	if err := h.Repository.UpdateImportJobProgress(ctx, &job); err != nil {
+		log.Error("import: failed to start job", zap.Error(err))
+	}
+
+	if !job.DryRun {
+		for _, company := range companies {
+			if strings.TrimSpace(company.Name) == "" {
+				continue
+			}
+			if _, err := h.resolveCompany(ctx, job.UserID, company.Name); err != nil {
+				log.Warn("import: failed to create company", zap.String("company", company.Name), zap.Error(err))
+			}
+		}
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		if len(row.Errors) == 0 {
+			if job.DryRun {
+				job.CreatedCount++
+			} else if err := h.importRow(ctx, &job, row); errors.Is(err, repository.ErrInterviewExists) {
+				addImportErrors(&job, model.ImportRowError{Row: row.Line, Field: "canonical_url", Message: "an interview from this URL already exists"})
+			} else if err != nil {
+				log.Warn("import: row failed", zap.Int("row", row.Line), zap.Error(err))
+				addImportErrors(&job, model.ImportRowError{Row: row.Line, Message: "failed to import row"})
+			} else {
+				job.CreatedCount++
+			}
+		}
+
+		job.ProcessedRows++
+		if job.ProcessedRows%importProgressEvery == 0 {
+			if err := h.Repository.UpdateImportJobProgress(ctx, &job); err != nil {
+				log.Warn("import: failed to update progress", zap.Error(err))
+			}
+		}
+	}
+
+	job.Status = model.ImportJobCompleted
+	if err := h.Repository.FinishImportJob(ctx, &job, nil); err != nil {
+		log.Error("import: failed to finish job", zap.Error(err))
+	}
+
+	if !job.DryRun && job.CreatedCount > 0 {
+		h.enrichQuestions(ctx, job.UserID, nil)
+	}
+
+	log.Info("import: job completed",
+		zap.Int("created", job.CreatedCount),
+		zap.Int("errors", job.ErrorCount),
+	)
+}
+
+// importRow creates the interview of a single row, extracting questions from its text
+// when the job asks for it and the row has none
+func (h *Handler) importRow(ctx context.Context, job *model.ImportJob, row *importer.Row) error {
+	iv := &row.Interview
+
+	if job.ExtractQuestions && len(iv.Questions) == 0 {
+		text := iv.FullExperience
+		if text == "" {
+			text = iv.RawInput
+		}
+		extracted, err := h.GroqClient.InterviewQuestions(ctx, text)
+		if err != nil {
+			// the interview is still worth importing without its questions
+			addImportErrors(job, model.ImportRowError{Row: row.Line, Field: "questions", Message: "question extraction failed"})
+		} else {
+			for _, q := range *extracted {
+				iv.Questions = append(iv.Questions, model.ExportQuestion{Question: q.Question, Type: q.Type})
+			}
+		}
+	}
+
+	if iv.Title == "" {
+		position := "Interview"
+		if iv.Position != nil && *iv.Position != "" {
+			position = *iv.Position + " Interview"
+		}
+		iv.Title = position + " at " + iv.Company
+	}
+
+	companyID, err := h.resolveCompany(ctx, job.UserID, iv.Company)
+	if err != nil {
+		return err
+	}
+
+	_, qIDs, err := h.Repository.ImportInterview(ctx, job.UserID, companyID, iv)
+	if err != nil {
+		return err
+	}
+
+	for i, q := range iv.Questions {
+		if q.LeetcodeSlug == nil {
+			continue
+		}
+		problem, ok := h.Problems.BySlug(*q.LeetcodeSlug)
+		if !ok {
+			continue
+		}
+		if err := h.Repository.SetQuestionLeetcode(ctx, qIDs[i], leetcodeLink(problem, 1, model.LeetcodeLinkConfirmed)); err != nil {
+			h.Logger.Warn("import: failed to restore leetcode link",
+				zap.Int64("question_id", qIDs[i]),
+				zap.Error(err),
+			)
+		}
+	}
+	return nil
+}
+
+// addImportErrors records row errors on a job, keeping at most maxImportErrors of them
+func addImportErrors(job *model.ImportJob, errs ...model.ImportRowError) {
+	job.ErrorCount += len(errs)
+	for _, e := range errs {
+		if len(job.Errors) >= maxImportErrors {
+			return
+		}
+		job.Errors = append(job.Errors, e)
+	}
+}
+
+// GetImportJob returns the progress and errors of an import job
+func (h *Handler) GetImportJob(c *gin.Context) {
+	jobID, err := strconv.ParseInt(c.Param("job_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid job_id format")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	job, err := h.Repository.GetImportJob(c.Request.Context(), claims.UserID, jobID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			response.NotFound(c, "import job not found")
+			return
+		}
+		h.Logger.Error("get_import_job: failed to fetch",
+			zap.Int64("job_id", jobID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch import job")
+		return
+	}
+
+	response.OK(c, job)
+}
+
+// ListImportJobs returns the import jobs of the current user, newest first
+func (h *Handler) ListImportJobs(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	var q model.ImportJobListQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "invalid query parameters")
+		return
+	}
+	if q.Limit <= 0 || q.Limit > 100 {
+		q.Limit = 20
+	}
+
+	jobs, total, err := h.Repository.ListImportJobs(c.Request.Context(), claims.UserID, q.Limit, q.Offset)
+	if err != nil {
+		h.Logger.Error("list_import_jobs: failed to fetch",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch import jobs")
+		return
+	}
+
+	response.OKWithMeta(c, jobs, &response.Meta{
+		Total:   total,
+		HasNext: total > q.Offset+len(jobs),
+	})
+}
@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/abhishek622/interviewMin/internal/fetcher"
+	"github.com/abhishek622/interviewMin/internal/repository"
+	"github.com/abhishek622/interviewMin/pkg"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// batchImportWorkers is how many URLs of a batch are fetched and extracted at the same time
+const batchImportWorkers = 3
+
+// BatchImportInterviews queues AI extraction for a list of post URLs. URLs that were
+// already imported, or listed twice, are reported as duplicates and not fetched again.
+func (h *Handler) BatchImportInterviews(c *gin.Context) {
+	var req model.BatchImportReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "urls must contain between 1 and 50 urls")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	batch, unknownCompanyID, err := h.queueImportBatch(c.Request.Context(), claims.UserID, req.URLs)
+	if err != nil {
+		h.Logger.Error("batch_import: failed to queue batch",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to create import batch")
+		return
+	}
+
+	response.Accepted(c, batch)
+
+	go h.runImportBatch(claims.UserID, unknownCompanyID, batch.BatchID, batch.Items)
+}
+
+// queueImportBatch stores urls as a batch import. Invalid URLs are stored as failed and
+// already imported ones as duplicates, the queued ones are left for runImportBatch.
+func (h *Handler) queueImportBatch(ctx context.Context, userID uuid.UUID, urls []string) (*model.ImportBatch, uuid.UUID, error) {
+	items := make([]model.ImportBatchItem, len(urls))
+	seen := map[string]bool{}
+	var canonical []string
+	for i, raw := range urls {
+		it := &items[i]
+		it.URL = strings.TrimSpace(raw)
+		it.Status = model.BatchItemQueued
+
+		source, err := fetcher.DetectSource(it.URL)
+		if err != nil {
+			it.Status = model.BatchItemFailed
+			it.Error = pkg.StringPtr(err.Error())
+			continue
+		}
+		it.Source = &source
+
+		canonicalURL, err := fetcher.CanonicalURL(it.URL, source)
+		if err != nil {
+			it.Status = model.BatchItemFailed
+			it.Error = pkg.StringPtr(err.Error())
+			continue
+		}
+		it.CanonicalURL = &canonicalURL
+
+		if seen[canonicalURL] {
+			it.Status = model.BatchItemDuplicate
+			it.Error = pkg.StringPtr("listed more than once in this batch")
+			continue
+		}
+		seen[canonicalURL] = true
+		canonical = append(canonical, canonicalURL)
+	}
+
+	imported, err := h.Repository.ImportedURLs(ctx, userID, canonical)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	for i := range items {
+		it := &items[i]
+		if it.Status != model.BatchItemQueued {
+			continue
+		}
+		if interviewID, ok := imported[*it.CanonicalURL]; ok {
+			it.Status = model.BatchItemDuplicate
+			it.InterviewID = &interviewID
+			it.Error = pkg.StringPtr("already imported")
+		}
+	}
+
+	unknownCompany, err := h.Repository.GetCompanyByName(ctx, userID, "unknown company")
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("get unknown company: %w", err)
+	}
+	if unknownCompany == nil {
+		return nil, uuid.Nil, errors.New("unknown company not found")
+	}
+
+	batchID, err := h.Repository.CreateImportBatch(ctx, userID, items)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	batch := &model.ImportBatch{
+		BatchID: batchID,
+		Total:   len(items),
+		Counts:  map[model.BatchItemStatus]int{},
+		Items:   items,
+	}
+	for _, it := range items {
+		batch.Counts[it.Status]++
+	}
+	batch.Done = batch.Counts[model.BatchItemQueued] == 0
+
+	h.Logger.Info("batch_import: batch queued",
+		zap.String("user_id", userID.String()),
+		zap.Int64("batch_id", batchID),
+		zap.Int("urls", len(items)),
+		zap.Int("queued", batch.Counts[model.BatchItemQueued]),
+	)
+	return batch, unknownCompany.CompanyID, nil
+}
+
+// runImportBatch fetches and extracts the queued URLs of a batch
+func (h *Handler) runImportBatch(userID, unknownCompanyID uuid.UUID, batchID int64, items []model.ImportBatchItem) {
+	ctx := context.Background()
+	log := h.Logger.With(zap.Int64("batch_id", batchID), zap.String("user_id", userID.String()))
+
+	queue := make(chan model.ImportBatchItem)
+	var wg sync.WaitGroup
+	for range batchImportWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for it := range queue {
+				h.runImportBatchItem(ctx, log, userID, unknownCompanyID, it)
+			}
+		}()
+	}
+
+	for _, it := range items {
+		if it.Status == model.BatchItemQueued {
+			queue <- it
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	if err := h.Repository.FinishImportBatch(ctx, batchID); err != nil {
+		log.Error("batch_import: failed to finish batch", zap.Error(err))
+	}
+	log.Info("batch_import: batch completed")
+}
+
+// runImportBatchItem imports one URL of a batch and records the outcome, a panic fails
+// the item instead of taking the process down
+func (h *Handler) runImportBatchItem(ctx context.Context, log *zap.Logger, userID, unknownCompanyID uuid.UUID, it model.ImportBatchItem) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("batch_import: item panicked", zap.Int64("item_id", it.ItemID), zap.Any("panic", r))
+			msg := "import stopped unexpectedly"
+			if it.InterviewID != nil {
+				if err := h.Repository.FailQueuedInterview(ctx, *it.InterviewID, msg); err != nil {
+					log.Error("batch_import: failed to fail queued interview", zap.Int64("interview_id", *it.InterviewID), zap.Error(err))
+				}
+			}
+			if err := h.Repository.UpdateImportBatchItem(ctx, it.ItemID, model.BatchItemFailed, nil, &msg); err != nil {
+				log.Error("batch_import: failed to fail item", zap.Int64("item_id", it.ItemID), zap.Error(err))
+			}
+		}
+	}()
+
+	status, interviewID, errMsg := h.importBatchItem(ctx, userID, unknownCompanyID, it)
+	if err := h.Repository.UpdateImportBatchItem(ctx, it.ItemID, status, interviewID, errMsg); err != nil {
+		log.Error("batch_import: failed to update item", zap.Int64("item_id", it.ItemID), zap.Error(err))
+	}
+}
+
+// importBatchItem fetches one URL and runs extraction over it
+func (h *Handler) importBatchItem(ctx context.Context, userID, unknownCompanyID uuid.UUID, it model.ImportBatchItem) (model.BatchItemStatus, *int64, *string) {
+	if err := h.Repository.UpdateImportBatchItem(ctx, it.ItemID, model.BatchItemProcessing, nil, nil); err != nil {
+		h.Logger.Warn("batch_import: failed to mark item processing", zap.Int64("item_id", it.ItemID), zap.Error(err))
+	}
+
+	res, err := fetcher.Fetch(ctx, it.URL, *it.Source)
+	if err != nil {
+		h.Logger.Warn("batch_import: fetch failed",
+			zap.String("url", it.URL),
+			zap.Error(err),
+		)
+		errMsg := "failed to fetch content from URL: " + err.Error()
+		if it.InterviewID != nil {
+			if err := h.Repository.FailQueuedInterview(ctx, *it.InterviewID, errMsg); err != nil {
+				h.Logger.Warn("batch_import: failed to fail queued interview", zap.Int64("interview_id", *it.InterviewID), zap.Error(err))
+			}
+		}
+		return model.BatchItemFailed, it.InterviewID, pkg.StringPtr(errMsg)
+	}
+
+	prov := fetchProvenance(it.URL, *it.CanonicalURL, res)
+	// items queued by a company watch already have their interview row
+	interviewID, err := h.extractAndSaveInterview(ctx, userID, unknownCompanyID, it.InterviewID, strings.TrimSpace(res.Title), strings.TrimSpace(res.Content), *it.Source, prov)
+	if !errors.Is(err, repository.ErrInterviewExists) {
+		h.saveSnapshot(ctx, interviewID, res.Raw)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrInterviewExists) {
+			return model.BatchItemDuplicate, interviewID, pkg.StringPtr("already imported")
+		}
+		return model.BatchItemFailed, interviewID, pkg.StringPtr(err.Error())
+	}
+	return model.BatchItemCompleted, interviewID, nil
+}
+
+// GetImportBatch returns the progress and errors of every URL in a batch import
+func (h *Handler) GetImportBatch(c *gin.Context) {
+	batchID, err := strconv.ParseInt(c.Param("batch_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid batch_id format")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	batch, err := h.Repository.GetImportBatch(c.Request.Context(), claims.UserID, batchID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			response.NotFound(c, "import batch not found")
+			return
+		}
+		h.Logger.Error("get_import_batch: failed to fetch",
+			zap.Int64("batch_id", batchID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch import batch")
+		return
+	}
+
+	response.OK(c, batch)
+}
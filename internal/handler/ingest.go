@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/abhishek622/interviewMin/internal/archive"
+	"github.com/abhishek622/interviewMin/internal/inbound"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+var (
+	ingestTokenEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+	// reply and forward prefixes, also the common German and Swedish ones
+	subjectPrefix = regexp.MustCompile(`(?i)^\s*((re|fwd?|aw|wg|sv|vs)\s*(\[\d+\])?\s*:\s*)+`)
+)
+
+func newIngestToken() (string, error) {
+	b := make([]byte, 15)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return strings.ToLower(ingestTokenEncoding.EncodeToString(b)), nil
+}
+
+func (h *Handler) ingestAddress(a *model.IngestAddress) *model.IngestAddress {
+	a.Address = a.Token + "@" + h.Config.Ingest.Domain
+	return a
+}
+
+// GetIngestAddress returns the address the current user emails interview notes to
+func (h *Handler) GetIngestAddress(c *gin.Context) {
+	if h.Config.Ingest.Domain == "" {
+		response.NotFound(c, "email ingestion is not enabled")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	addr, err := h.Repository.GetIngestAddress(c.Request.Context(), claims.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			response.NotFound(c, "no ingest address yet")
+			return
+		}
+		h.Logger.Error("get_ingest_address: failed to fetch",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch ingest address")
+		return
+	}
+
+	response.OK(c, h.ingestAddress(addr))
+}
+
+// CreateIngestAddress gives the current user a new ingest address, the previous one stops working
+func (h *Handler) CreateIngestAddress(c *gin.Context) {
+	if h.Config.Ingest.Domain == "" {
+		response.NotFound(c, "email ingestion is not enabled")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	token, err := newIngestToken()
+	if err != nil {
+		response.InternalError(c, "failed to create ingest address")
+		return
+	}
+
+	addr, err := h.Repository.SetIngestToken(c.Request.Context(), claims.UserID, token)
+	if err != nil {
+		h.Logger.Error("create_ingest_address: failed to save",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to create ingest address")
+		return
+	}
+
+	response.Created(c, h.ingestAddress(addr))
+}
+
+// IngestEmail is the webhook mail providers post raw MIME messages to. The message is
+// matched to a user by its ingest address, the "recipient" query parameter is checked
+// first for providers that pass the envelope recipient separately. The text is
+// extracted like pasted notes and the message is kept as an attachment of the saved
+// interview. A message whose extraction fails is forgotten so it can be sent again.
+func (h *Handler) IngestEmail(c *gin.Context) {
+	cfg := h.Config.Ingest
+	if cfg.Domain == "" {
+		response.NotFound(c, "email ingestion is not enabled")
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(c.GetHeader("X-Ingest-Secret")), []byte(cfg.WebhookSecret)) != 1 {
+		response.Unauthorized(c, "invalid ingest secret")
+		return
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(c.Request.Body, cfg.MaxMessageBytes+1))
+	if err != nil {
+		response.BadRequest(c, "failed to read message")
+		return
+	}
+	if int64(len(raw)) > cfg.MaxMessageBytes {
+		response.BadRequest(c, "message is too large")
+		return
+	}
+
+	msg, err := inbound.Parse(bytes.NewReader(raw))
+	if err != nil {
+		h.Logger.Warn("ingest_email: failed to parse message", zap.Error(err))
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	userID, err := h.ingestRecipient(ctx, append([]string{strings.ToLower(c.Query("recipient"))}, msg.Recipients...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			response.NotFound(c, "unknown ingest address")
+			return
+		}
+		h.Logger.Error("ingest_email: failed to resolve recipient", zap.Error(err))
+		response.InternalError(c, "failed to resolve recipient")
+		return
+	}
+
+	unknownCompany, err := h.Repository.GetCompanyByName(ctx, userID, "unknown company")
+	if err != nil || unknownCompany == nil {
+		h.Logger.Error("ingest_email: failed to get unknown company",
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to get unknown company")
+		return
+	}
+
+	if msg.MessageID != "" {
+		first, err := h.Repository.MarkEmailIngested(ctx, userID, msg.MessageID)
+		if err != nil {
+			h.Logger.Error("ingest_email: failed to record message",
+				zap.String("user_id", userID.String()),
+				zap.Error(err),
+			)
+			response.InternalError(c, "failed to record message")
+			return
+		}
+		if !first {
+			response.Message(c, "message was already ingested")
+			return
+		}
+	}
+
+	title := subjectPrefix.ReplaceAllString(msg.Subject, "")
+	attachment := &model.Attachment{
+		Filename:    attachmentName(title) + ".eml",
+		ContentType: "message/rfc822",
+		Size:        len(raw),
+		BlobKey:     archive.AttachmentKey(raw),
+	}
+
+	h.Logger.Info("ingest_email: message accepted",
+		zap.String("user_id", userID.String()),
+		zap.String("message_id", msg.MessageID),
+	)
+	response.Accepted(c, gin.H{"message": "Interview processing started, it will be added soon"})
+
+	go func(unknownCompanyID uuid.UUID) {
+		ctx := context.Background()
+		log := h.Logger.With(zap.String("user_id", userID.String()), zap.String("message_id", msg.MessageID))
+
+		interviewID, err := h.extractAndSaveInterview(ctx, userID, unknownCompanyID, nil, title, msg.Text, model.SourcePersonal, model.Provenance{})
+		if err != nil && msg.MessageID != "" {
+			// the message was not turned into an interview, sending it again must not be
+			// answered as already ingested
+			if err := h.Repository.ForgetIngestedEmail(ctx, userID, msg.MessageID); err != nil {
+				log.Error("ingest_email: failed to forget message", zap.Error(err))
+			}
+		}
+		if interviewID == nil {
+			return
+		}
+
+		// the message is only stored once there is an interview to attach it to
+		if err := h.Archive.Put(ctx, attachment.BlobKey, &archive.Blob{ContentType: attachment.ContentType, Data: raw}); err != nil {
+			log.Warn("ingest_email: failed to store message", zap.Error(err))
+			return
+		}
+		attachment.InterviewID = *interviewID
+		if err := h.Repository.CreateAttachment(ctx, userID, attachment); err != nil {
+			log.Error("ingest_email: failed to save attachment",
+				zap.Int64("interview_id", *interviewID),
+				zap.Error(err),
+			)
+		}
+	}(unknownCompany.CompanyID)
+}
+
+// ingestRecipient returns the owner of the first address on the ingest domain. A
+// "+tag" suffix of the local part is ignored.
+func (h *Handler) ingestRecipient(ctx context.Context, addresses []string) (uuid.UUID, error) {
+	for _, addr := range addresses {
+		local, domain, ok := strings.Cut(addr, "@")
+		if !ok || !strings.EqualFold(domain, h.Config.Ingest.Domain) {
+			continue
+		}
+		token, _, _ := strings.Cut(local, "+")
+		return h.Repository.GetUserIDByIngestToken(ctx, token)
+	}
+	return uuid.Nil, pgx.ErrNoRows
+}
+
+// attachmentName makes a subject usable as a file name
+func attachmentName(subject string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r == '/' || r == '\\' || r == '"' || r < ' ':
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(subject))
+	if name == "" {
+		return "message"
+	}
+	if len(name) > 100 {
+		name = strings.ToValidUTF8(name[:100], "")
+	}
+	return name
+}
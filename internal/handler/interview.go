@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/abhishek622/interviewMin/internal/fetcher"
+	"github.com/abhishek622/interviewMin/internal/repository"
 	"github.com/abhishek622/interviewMin/pkg"
 	"github.com/abhishek622/interviewMin/pkg/model"
 	"github.com/abhishek622/interviewMin/pkg/response"
@@ -33,11 +35,39 @@ func (h *Handler) CreateInterviewWithAI(c *gin.Context) {
 
 	var contentToProcess string
 	var fetchedTitle string
+	var provenance model.Provenance
+	var raw *fetcher.RawResponse
 
-	if req.Source == model.SourceOther || req.Source == model.SourcePersonal {
+	// other accepts either pasted text or the URL of any article
+	if req.Source == model.SourcePersonal || (req.Source == model.SourceOther && !fetcher.IsURL(req.RawInput)) {
 		contentToProcess = req.RawInput
 	} else {
-		res, err := fetcher.Fetch(req.RawInput, req.Source, c.Request.UserAgent())
+		sourceURL := strings.TrimSpace(req.RawInput)
+		canonicalURL, err := fetcher.CanonicalURL(sourceURL, req.Source)
+		if err != nil {
+			response.BadRequest(c, err.Error())
+			return
+		}
+
+		existingID, err := h.Repository.InterviewIDByCanonicalURL(c.Request.Context(), claims.UserID, canonicalURL)
+		if err == nil {
+			response.OK(c, gin.H{
+				"message":          "interview already imported",
+				"already_imported": true,
+				"interview_id":     existingID,
+			})
+			return
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			h.Logger.Error("create_interview_ai: failed to check imported url",
+				zap.String("user_id", claims.UserID.String()),
+				zap.Error(err),
+			)
+			response.InternalError(c, "failed to check imported url")
+			return
+		}
+
+		res, err := fetcher.Fetch(c.Request.Context(), sourceURL, req.Source)
 		if err != nil {
 			h.Logger.Warn("create_interview_ai: fetch failed",
 				zap.String("source", string(req.Source)),
@@ -48,16 +78,10 @@ func (h *Handler) CreateInterviewWithAI(c *gin.Context) {
 		}
 		contentToProcess = strings.TrimSpace(res.Content)
 		fetchedTitle = strings.TrimSpace(res.Title)
+		provenance = fetchProvenance(sourceURL, canonicalURL, res)
+		raw = res.Raw
 	}
 
-	// Construct Metadata
-	metadata := map[string]interface{}{
-		"title":           fetchedTitle,
-		"full_experience": contentToProcess,
-	}
-
-	contentToProcess = fmt.Sprintf("%s\n\n%s", fetchedTitle, contentToProcess)
-
 	unknownCompany, err := h.Repository.GetCompanyByName(c.Request.Context(), claims.UserID, "unknown company")
 	if err != nil {
 		h.Logger.Error("create_interview_ai: failed to get unknown company",
@@ -75,110 +99,177 @@ func (h *Handler) CreateInterviewWithAI(c *gin.Context) {
 	response.OK(c, gin.H{"message": "Interview processing started, it will be added soon"})
 
 	// Background process
-	go func(userID uuid.UUID, content string, source model.Source, meta map[string]interface{}) {
+	go func(userID, unknownCompanyID uuid.UUID, title, content string, source model.Source, prov model.Provenance) {
 		ctx := context.Background()
+		interviewID, _ := h.extractAndSaveInterview(ctx, userID, unknownCompanyID, nil, title, content, source, prov)
+		h.saveSnapshot(ctx, interviewID, raw)
+	}(claims.UserID, unknownCompany.CompanyID, fetchedTitle, contentToProcess, req.Source, provenance)
+}
 
-		extracted, err := h.GroqClient.ExtractInterview(ctx, content)
-		if err != nil {
-			h.Logger.Error("create_interview_ai: extraction failed",
-				zap.String("user_id", userID.String()),
-				zap.Error(err),
-			)
-
-			// create interview in unknown company
-			_, err = h.Repository.CreateInterview(ctx, &model.Interview{
-				UserID:        userID,
-				Source:        source,
-				RawInput:      content,
-				ProcessStatus: model.ProcessStatusFailed,
-				Metadata:      meta,
-				CompanyID:     unknownCompany.CompanyID,
-				ProcessError:  pkg.StringPtr(err.Error()),
-			})
-			if err != nil {
-				h.Logger.Error("create_interview_ai: failed to create interview",
-					zap.String("user_id", userID.String()),
-					zap.Error(err),
-				)
-			}
-			return
-		}
-
-		var companyID uuid.UUID
-		companyName := strings.TrimSpace(strings.ToLower(extracted.Company))
+// fetchProvenance describes where fetched content came from
+func fetchProvenance(sourceURL, canonicalURL string, res *fetcher.FetchResult) model.Provenance {
+	fetchedAt := time.Now().UTC()
+	return model.Provenance{
+		SourceURL:         &sourceURL,
+		CanonicalURL:      &canonicalURL,
+		FetchedAt:         &fetchedAt,
+		SourcePublishedAt: res.PublishedAt,
+		ContentHash:       pkg.StringPtr(res.Hash()),
+	}
+}
 
-		if companyName != "" {
-			// Find existing company
-			company, _ := h.Repository.GetCompanyByName(ctx, userID, companyName)
-			if company != nil {
-				companyID = company.CompanyID
-			}
+// extractAndSaveInterview runs AI extraction over fetched or pasted content and saves the
+// interview with its questions. When extraction fails the interview is still saved as
+// failed under the unknown company, its id is returned together with the error.
+// repository.ErrInterviewExists is returned when the URL in prov was imported meanwhile.
+// A non nil queuedID is the queued interview the result is stored in instead of a new one.
+func (h *Handler) extractAndSaveInterview(ctx context.Context, userID, unknownCompanyID uuid.UUID, queuedID *int64, title, content string, source model.Source, prov model.Provenance) (*int64, error) {
+	meta := map[string]interface{}{
+		"title":           title,
+		"full_experience": content,
+	}
+	content = fmt.Sprintf("%s\n\n%s", title, content)
 
-			if companyID == uuid.Nil {
-				companySlug := pkg.GenerateSlug(companyName)
-				newCompany := &model.Company{
-					Name:   companyName,
-					Slug:   companySlug,
-					UserID: userID,
-				}
-				newCompanyID, err := h.Repository.CreateCompany(ctx, newCompany)
-				if err != nil {
-					h.Logger.Error("create_interview_ai: failed to create company",
-						zap.String("company_name", companyName),
-						zap.Error(err),
-					)
-				} else if newCompanyID != nil {
-					companyID = *newCompanyID
-				}
-			}
-		}
+	tagVocabulary, err := h.Repository.ListTagNames(ctx, userID)
+	if err != nil {
+		h.Logger.Warn("create_interview_ai: failed to load tag vocabulary",
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+	}
 
-		if companyID == uuid.Nil {
-			companyID = unknownCompany.CompanyID
-		}
+	extracted, err := h.GroqClient.ExtractInterview(ctx, content, tagVocabulary)
+	if err != nil {
+		h.Logger.Error("create_interview_ai: extraction failed",
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
 
-		interviewID, err := h.Repository.CreateInterview(ctx, &model.Interview{
+		// create interview in unknown company. It keeps no canonical URL, so importing the
+		// URL again is not refused as a duplicate, and no content hash, so a resync
+		// re-extracts it instead of finding it unchanged.
+		failedProv := prov
+		failedProv.CanonicalURL = nil
+		failedProv.ContentHash = nil
+		interviewID, createErr := h.saveInterview(ctx, queuedID, &model.Interview{
 			UserID:        userID,
 			Source:        source,
 			RawInput:      content,
-			ProcessStatus: model.ProcessStatusSuccess,
+			ProcessStatus: model.ProcessStatusFailed,
 			Metadata:      meta,
-			CompanyID:     companyID,
-			Position:      &extracted.Position,
-			NoOfRound:     &extracted.NoOfRound,
-			Location:      &extracted.Location,
+			CompanyID:     unknownCompanyID,
+			ProcessError:  pkg.StringPtr(err.Error()),
+			Provenance:    failedProv,
 		})
-		if err != nil {
+		if createErr != nil {
 			h.Logger.Error("create_interview_ai: failed to create interview",
 				zap.String("user_id", userID.String()),
-				zap.Error(err),
+				zap.Error(createErr),
 			)
-			return
 		}
+		return interviewID, fmt.Errorf("extraction failed: %w", err)
+	}
 
-		// Save Questions
-		if len(extracted.Questions) > 0 {
-			qs := make([]model.Question, len(extracted.Questions))
-			for i, q := range extracted.Questions {
-				qs[i] = model.Question{
-					InterviewID: *interviewID,
-					Question:    q.Question,
-					Type:        q.Type,
-				}
-			}
-			if err := h.Repository.CreateQuestions(ctx, qs); err != nil {
-				h.Logger.Error("create_interview_ai: failed to save questions",
-					zap.Int64("interview_id", *interviewID),
-					zap.Error(err),
-				)
-			}
+	var companyID uuid.UUID
+	companyName := strings.TrimSpace(strings.ToLower(extracted.Company))
+
+	if companyName != "" {
+		companyID, err = h.resolveCompany(ctx, userID, companyName)
+		if err != nil {
+			h.Logger.Error("create_interview_ai: failed to create company",
+				zap.String("company_name", companyName),
+				zap.Error(err),
+			)
 		}
+	}
 
-		h.Logger.Info("create_interview_ai: interview created successfully",
+	if companyID == uuid.Nil {
+		companyID = unknownCompanyID
+	}
+
+	if len(extracted.Tags) > 0 {
+		meta["suggested_tags"] = extracted.Tags
+	}
+
+	interviewID, err := h.saveInterview(ctx, queuedID, &model.Interview{
+		UserID:        userID,
+		Source:        source,
+		RawInput:      content,
+		ProcessStatus: model.ProcessStatusSuccess,
+		Metadata:      meta,
+		CompanyID:     companyID,
+		Position:      &extracted.Position,
+		NoOfRound:     &extracted.NoOfRound,
+		Location:      &extracted.Location,
+		Provenance:    prov,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrInterviewExists) {
+			existingID, _ := h.Repository.InterviewIDByCanonicalURL(ctx, userID, *prov.CanonicalURL)
+			return &existingID, err
+		}
+		h.Logger.Error("create_interview_ai: failed to create interview",
 			zap.String("user_id", userID.String()),
-			zap.Int64("interview_id", *interviewID),
+			zap.Error(err),
 		)
-	}(claims.UserID, contentToProcess, req.Source, metadata)
+		return nil, err
+	}
+
+	// Save Questions
+	if len(extracted.Questions) > 0 {
+		qs := make([]model.Question, len(extracted.Questions))
+		for i, q := range extracted.Questions {
+			qs[i] = model.Question{
+				InterviewID: *interviewID,
+				Question:    q.Question,
+				Type:        q.Type,
+			}
+		}
+		if err := h.Repository.CreateQuestions(ctx, qs); err != nil {
+			h.Logger.Error("create_interview_ai: failed to save questions",
+				zap.Int64("interview_id", *interviewID),
+				zap.Error(err),
+			)
+		} else {
+			h.enrichQuestions(ctx, userID, interviewID)
+		}
+	}
+
+	h.Logger.Info("create_interview_ai: interview created successfully",
+		zap.String("user_id", userID.String()),
+		zap.Int64("interview_id", *interviewID),
+	)
+	return interviewID, nil
+}
+
+// saveInterview creates an interview, or fills the queued one with the given id
+func (h *Handler) saveInterview(ctx context.Context, queuedID *int64, interview *model.Interview) (*int64, error) {
+	if queuedID == nil {
+		return h.Repository.CreateInterview(ctx, interview)
+	}
+	if err := h.Repository.FillQueuedInterview(ctx, *queuedID, interview); err != nil {
+		return nil, err
+	}
+	return queuedID, nil
+}
+
+// resolveCompany returns the user's company with the given name, creating it when it does not exist yet
+func (h *Handler) resolveCompany(ctx context.Context, userID uuid.UUID, name string) (uuid.UUID, error) {
+	companyName := strings.ToLower(name)
+	company, _ := h.Repository.GetCompanyByName(ctx, userID, companyName)
+	if company != nil {
+		return company.CompanyID, nil
+	}
+
+	newCompanyID, err := h.Repository.CreateCompany(ctx, &model.Company{
+		Name:   companyName,
+		Slug:   pkg.GenerateSlug(companyName),
+		UserID: userID,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return *newCompanyID, nil
 }
 
 // CreateInterview creates an interview with manual input
@@ -201,28 +292,16 @@ func (h *Handler) CreateInterview(c *gin.Context) {
 	}
 
 	if req.CompanyID == nil {
-		companyName := strings.ToLower(req.Company)
-		companySlug := pkg.GenerateSlug(companyName)
-		company, _ := h.Repository.GetCompanyByName(c.Request.Context(), claims.UserID, companyName)
-		if company != nil {
-			req.CompanyID = &company.CompanyID
-		} else {
-			newCompany := &model.Company{
-				Name:   companyName,
-				Slug:   companySlug,
-				UserID: claims.UserID,
-			}
-			newCompanyID, err := h.Repository.CreateCompany(c.Request.Context(), newCompany)
-			if err != nil {
-				h.Logger.Error("create_interview: failed to create company",
-					zap.String("company_name", companyName),
-					zap.Error(err),
-				)
-				response.InternalError(c, "failed to create company")
-				return
-			}
-			req.CompanyID = newCompanyID
+		companyID, err := h.resolveCompany(c.Request.Context(), claims.UserID, req.Company)
+		if err != nil {
+			h.Logger.Error("create_interview: failed to create company",
+				zap.String("company_name", req.Company),
+				zap.Error(err),
+			)
+			response.InternalError(c, "failed to create company")
+			return
 		}
+		req.CompanyID = &companyID
 	}
 
 	createObj := model.Interview{
@@ -256,9 +335,10 @@ func (h *Handler) CreateInterview(c *gin.Context) {
 		"message":      "interview created successfully",
 		"interview_id": interviewID,
 	})
+	h.indexEmbeddingsInBackground(claims.UserID)
 
 	// Background question extraction
-	go func(interviewID int64, content string) {
+	go func(userID uuid.UUID, interviewID int64, content string) {
 		ctx := context.Background()
 		extracted, err := h.GroqClient.InterviewQuestions(ctx, content)
 		if err != nil {
@@ -283,9 +363,11 @@ func (h *Handler) CreateInterview(c *gin.Context) {
 					zap.Int64("interview_id", interviewID),
 					zap.Error(err),
 				)
+			} else {
+				h.enrichQuestions(ctx, userID, &interviewID)
 			}
 		}
-	}(*interviewID, req.RawInput)
+	}(claims.UserID, *interviewID, req.RawInput)
 }
 
 // ListInterviews returns a paginated list of interviews for a company
@@ -329,6 +411,9 @@ func (h *Handler) ListInterviews(c *gin.Context) {
 			}
 			filters["process_status"] = statusStrings
 		}
+		if q.Filter.Tags != nil && len(*q.Filter.Tags) > 0 {
+			filters["tags"] = *q.Filter.Tags
+		}
 	}
 
 	data, total, err := h.Repository.ListInterviewByCompany(c.Request.Context(), q.CompanyID, limit, offset, filters, q.Search)
@@ -427,6 +512,16 @@ func (h *Handler) GetInterview(c *gin.Context) {
 
 	interview.CompanyName = &company.Name
 
+	tags, err := h.Repository.ListInterviewTags(c.Request.Context(), id)
+	if err != nil {
+		h.Logger.Warn("get_interview: failed to fetch tags",
+			zap.Int64("interview_id", id),
+			zap.Error(err),
+		)
+		tags = []model.Tag{}
+	}
+	interview.Tags = tags
+
 	// // Fetch questions
 	// questions, err := h.Repository.ListQuestionByInterviewID(c.Request.Context(), id)
 	// if err != nil {
@@ -502,11 +597,12 @@ func (h *Handler) PatchInterview(c *gin.Context) {
 	if req.Title != nil {
 		metadata["title"] = req.Title
 	}
+	updates := make(map[string]interface{})
 	if req.RawInput != nil {
 		metadata["full_experience"] = strings.TrimSpace(*req.RawInput)
+		updates["language"] = pkg.DetectLanguage(*req.RawInput)
 	}
 
-	updates := make(map[string]interface{})
 	if len(metadata) > 0 {
 		updates["metadata"] = metadata
 	}
@@ -533,6 +629,7 @@ func (h *Handler) PatchInterview(c *gin.Context) {
 	h.Logger.Info("patch_interview: interview updated",
 		zap.Int64("interview_id", interviewID),
 	)
+	h.indexEmbeddingsInBackground(currInterview.UserID)
 
 	response.Message(c, "interview updated successfully")
 }
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/abhishek622/interviewMin/internal/leetcode"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// leetcodeMinScore is the lowest similarity for a problem to be considered at all
+	leetcodeMinScore = 0.5
+	// leetcodeAutoScore is the similarity above which a clear winner is linked without the LLM
+	leetcodeAutoScore = 0.85
+	// leetcodeAutoMargin is how far the winner must be ahead of the runner-up
+	leetcodeAutoMargin = 0.1
+	leetcodeCandidates = 5
+)
+
+// ListLeetcodeCandidates returns the best matching LeetCode problems for a question
+func (h *Handler) ListLeetcodeCandidates(c *gin.Context) {
+	questionID, err := strconv.ParseInt(c.Param("q_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid question_id format")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	question, ok := h.ownedQuestion(c, questionID, claims.UserID)
+	if !ok {
+		return
+	}
+
+	matches := h.Problems.Match(question.Question, leetcodeMinScore, leetcodeCandidates)
+	out := make([]model.LeetcodeCandidate, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, model.LeetcodeCandidate{
+			Slug:       m.Problem.Slug,
+			Title:      m.Problem.Title,
+			Difficulty: m.Problem.Difficulty,
+			Topics:     m.Problem.Topics,
+			Score:      m.Score,
+		})
+	}
+
+	response.OK(c, out)
+}
+
+// SetQuestionLeetcode confirms or corrects the LeetCode problem linked to a question
+func (h *Handler) SetQuestionLeetcode(c *gin.Context) {
+	questionID, err := strconv.ParseInt(c.Param("q_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid question_id format")
+		return
+	}
+
+	var req model.SetLeetcodeLinkReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	if _, ok := h.ownedQuestion(c, questionID, claims.UserID); !ok {
+		return
+	}
+
+	problem, ok := h.Problems.BySlug(req.Slug)
+	if !ok {
+		response.BadRequest(c, "unknown leetcode problem slug")
+		return
+	}
+
+	link := leetcodeLink(problem, 1, model.LeetcodeLinkConfirmed)
+	if err := h.Repository.SetQuestionLeetcode(c.Request.Context(), questionID, link); err != nil {
+		h.Logger.Error("set_question_leetcode: failed to update",
+			zap.Int64("question_id", questionID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to update leetcode link")
+		return
+	}
+
+	response.OK(c, link)
+}
+
+// DeleteQuestionLeetcode removes a LeetCode link and stops it from being suggested again
+func (h *Handler) DeleteQuestionLeetcode(c *gin.Context) {
+	questionID, err := strconv.ParseInt(c.Param("q_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid question_id format")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	if _, ok := h.ownedQuestion(c, questionID, claims.UserID); !ok {
+		return
+	}
+
+	if err := h.Repository.ClearQuestionLeetcode(c.Request.Context(), questionID, model.LeetcodeLinkRejected); err != nil {
+		h.Logger.Error("delete_question_leetcode: failed to clear",
+			zap.Int64("question_id", questionID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to remove leetcode link")
+		return
+	}
+
+	response.Message(c, "leetcode link removed successfully")
+}
+
+// RefreshLeetcodeProblems reloads the LeetCode problem list from LeetCode (admin only)
+func (h *Handler) RefreshLeetcodeProblems(c *gin.Context) {
+	count, err := h.Problems.Refresh(c.Request.Context())
+	if err != nil {
+		h.Logger.Error("refresh_leetcode_problems: failed to refresh",
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to refresh leetcode problems")
+		return
+	}
+
+	h.Logger.Info("refresh_leetcode_problems: catalog refreshed",
+		zap.Int("count", count),
+	)
+
+	response.OK(c, gin.H{"problems": count})
+}
+
+// RunLeetcodeRefresher downloads the full problem list when the catalog only holds the
+// embedded seed, and again every configured interval, until ctx is cancelled
+func (h *Handler) RunLeetcodeRefresher(ctx context.Context) {
+	interval := h.Config.Leetcode.RefreshInterval
+	if h.Problems.Seeded() {
+		h.refreshLeetcodeProblems(ctx)
+	}
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.refreshLeetcodeProblems(ctx)
+		}
+	}
+}
+
+func (h *Handler) refreshLeetcodeProblems(ctx context.Context) {
+	count, err := h.Problems.Refresh(ctx)
+	if err != nil {
+		h.Logger.Warn("leetcode_refresher: failed to refresh", zap.Error(err))
+		return
+	}
+	h.Logger.Info("leetcode_refresher: catalog refreshed", zap.Int("count", count))
+}
+
+// ownedQuestion loads a question and writes a 404 unless it belongs to the user
+func (h *Handler) ownedQuestion(c *gin.Context, questionID int64, userID uuid.UUID) (*model.QuestionRes, bool) {
+	owned, err := h.Repository.QuestionBelongsToUser(c.Request.Context(), questionID, userID)
+	if err != nil || !owned {
+		response.NotFound(c, "question not found")
+		return nil, false
+	}
+
+	question, err := h.Repository.GetQuestionByID(c.Request.Context(), questionID)
+	if err != nil {
+		response.NotFound(c, "question not found")
+		return nil, false
+	}
+
+	return question, true
+}
+
+// linkLeetcodeProblems suggests LeetCode problems for dsa questions that have not been
+// matched yet. Clear winners are linked directly, close calls go to the LLM.
+func (h *Handler) linkLeetcodeProblems(ctx context.Context, userID uuid.UUID, interviewID *int64) (int, error) {
+	questions, err := h.Repository.ListUnmatchedDSAQuestions(ctx, userID, interviewID)
+	if err != nil {
+		return 0, err
+	}
+
+	linked := 0
+	for _, q := range questions {
+		matches := h.Problems.Match(q.Question, leetcodeMinScore, leetcodeCandidates)
+
+		var pick *leetcode.Candidate
+		switch {
+		case len(matches) == 0:
+		case matches[0].Score >= leetcodeAutoScore && (len(matches) == 1 || matches[0].Score-matches[1].Score >= leetcodeAutoMargin):
+			pick = &matches[0]
+		case h.GroqClient != nil:
+			titles := make([]string, len(matches))
+			for i, m := range matches {
+				titles[i] = m.Problem.Title
+			}
+			idx, err := h.GroqClient.MatchProblem(ctx, q.Question, titles)
+			if err != nil {
+				h.Logger.Warn("link_leetcode: llm disambiguation failed",
+					zap.Int64("question_id", q.QID),
+					zap.Error(err),
+				)
+				continue // leave unmatched so a later run retries
+			}
+			if idx >= 0 {
+				pick = &matches[idx]
+			}
+		}
+
+		if pick == nil {
+			if err := h.Repository.ClearQuestionLeetcode(ctx, q.QID, model.LeetcodeLinkUnmatched); err != nil {
+				return linked, err
+			}
+			continue
+		}
+
+		link := leetcodeLink(pick.Problem, pick.Score, model.LeetcodeLinkSuggested)
+		if err := h.Repository.SetQuestionLeetcode(ctx, q.QID, link); err != nil {
+			return linked, err
+		}
+		linked++
+	}
+
+	return linked, nil
+}
+
+func leetcodeLink(p leetcode.Problem, score float64, status model.LeetcodeLinkStatus) *model.LeetcodeLink {
+	topics := p.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	return &model.LeetcodeLink{
+		Slug:       p.Slug,
+		Title:      p.Title,
+		URL:        "https://leetcode.com/problems/" + p.Slug + "/",
+		Difficulty: p.Difficulty,
+		Topics:     topics,
+		Score:      float32(score),
+		Status:     status,
+	}
+}
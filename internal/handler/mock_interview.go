@@ -0,0 +1,390 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/abhishek622/interviewMin/internal/groq"
+	"github.com/abhishek622/interviewMin/internal/practice"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// mockQuestionTypes are the question types a mock interview is built from, in loop order
+var mockQuestionTypes = []string{"dsa", "system_design", "behavioral"}
+
+var mockRoundNames = map[string]string{
+	"dsa":           "Coding",
+	"system_design": "System Design",
+	"behavioral":    "Behavioral",
+}
+
+// CreateMockInterview builds a mock interview from the questions a company asked before.
+// Frequently asked questions are more likely to be picked.
+func (h *Handler) CreateMockInterview(c *gin.Context) {
+	companyID, err := uuid.Parse(c.Param("company_id"))
+	if err != nil {
+		response.BadRequest(c, "invalid company_id format")
+		return
+	}
+
+	var req model.CreateMockInterviewReq
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, "invalid request body")
+			return
+		}
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	company, err := h.Repository.CompanyDetails(c.Request.Context(), claims.UserID, companyID)
+	if err != nil {
+		response.NotFound(c, "company not found")
+		return
+	}
+
+	pool, err := h.Repository.CompanyQuestionPool(c.Request.Context(), claims.UserID, companyID)
+	if err != nil {
+		h.Logger.Error("create_mock_interview: failed to fetch question pool",
+			zap.String("company_id", companyID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to create mock interview")
+		return
+	}
+	if len(pool) == 0 {
+		response.ValidationError(c, "no questions recorded for this company yet")
+		return
+	}
+
+	counts := map[string]int{
+		"dsa":           valueOr(req.DSA, 2),
+		"system_design": valueOr(req.SystemDesign, 1),
+		"behavioral":    valueOr(req.Behavioral, 2),
+	}
+
+	byType := map[string][]model.MockPoolQuestion{}
+	for _, p := range pool {
+		byType[p.Type] = append(byType[p.Type], p)
+	}
+
+	rng := rand.New(rand.NewPCG(uint64(time.Now().UnixNano()), 0))
+	var (
+		selected []model.MockPoolQuestion
+		missing  []string
+	)
+	for _, t := range mockQuestionTypes {
+		if counts[t] == 0 {
+			continue
+		}
+		candidates := byType[t]
+		if len(candidates) == 0 {
+			missing = append(missing, t)
+			continue
+		}
+		weights := make([]int, len(candidates))
+		for i, cand := range candidates {
+			weights[i] = cand.TimesAsked
+		}
+		for _, idx := range practice.WeightedSample(weights, counts[t], rng) {
+			selected = append(selected, candidates[idx])
+		}
+	}
+	if len(selected) == 0 {
+		response.ValidationError(c, "no questions of the requested types recorded for this company")
+		return
+	}
+
+	mock := &model.MockInterview{
+		UserID:      claims.UserID,
+		CompanyID:   companyID,
+		CompanyName: company.Name,
+		Title:       fmt.Sprintf("%s mock interview", company.Name),
+		Status:      model.MockInterviewInProgress,
+	}
+
+	var rounds []groq.MockRound
+	if h.GroqClient != nil {
+		candidates := make([]groq.MockCandidate, len(selected))
+		for i, s := range selected {
+			candidates[i] = groq.MockCandidate{Question: s.Question, Type: s.Type, TimesAsked: s.TimesAsked}
+		}
+		rounds, err = h.GroqClient.PlanMockInterview(c.Request.Context(), company.Name, candidates, missing)
+		if err != nil {
+			h.Logger.Warn("create_mock_interview: ai planning failed, using default rounds",
+				zap.String("company_id", companyID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+	mock.Questions = mockQuestionsFromPlan(rounds, selected)
+
+	if err := h.Repository.CreateMockInterview(c.Request.Context(), mock); err != nil {
+		h.Logger.Error("create_mock_interview: failed to save",
+			zap.String("company_id", companyID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to create mock interview")
+		return
+	}
+
+	h.Logger.Info("create_mock_interview: mock interview created",
+		zap.Int64("mock_id", mock.MockID),
+		zap.Int("questions", len(mock.Questions)),
+	)
+
+	response.Created(c, mock)
+}
+
+// mockQuestionsFromPlan turns the AI plan into mock questions. Every selected question is
+// kept even if the plan dropped it, and plan entries pointing at unknown candidates are ignored.
+// Without a plan the questions are grouped into one round per type.
+func mockQuestionsFromPlan(rounds []groq.MockRound, selected []model.MockPoolQuestion) []model.MockInterviewQuestion {
+	var out []model.MockInterviewQuestion
+	used := make([]bool, len(selected))
+
+	roundNo := 0
+	for _, round := range rounds {
+		position := 0
+		for _, rq := range round.Questions {
+			mq := model.MockInterviewQuestion{RoundNo: roundNo + 1, RoundName: round.Name, Position: position + 1}
+			switch {
+			case rq.SourceIndex >= 0 && rq.SourceIndex < len(selected) && !used[rq.SourceIndex]:
+				src := selected[rq.SourceIndex]
+				used[rq.SourceIndex] = true
+				mq.Question, mq.Type, mq.TimesAsked = src.Question, src.Type, src.TimesAsked
+				mq.QID = &src.QID
+			case rq.SourceIndex == -1 && rq.Question != "" && mockRoundNames[rq.Type] != "":
+				mq.Question, mq.Type = rq.Question, rq.Type
+			default:
+				continue
+			}
+			out = append(out, mq)
+			position++
+		}
+		if position > 0 {
+			roundNo++
+		}
+	}
+
+	// whatever the plan left out goes into one round per type
+	for _, t := range mockQuestionTypes {
+		position := 0
+		for i, src := range selected {
+			if used[i] || src.Type != t {
+				continue
+			}
+			if position == 0 {
+				roundNo++
+			}
+			position++
+			out = append(out, model.MockInterviewQuestion{
+				RoundNo:    roundNo,
+				RoundName:  mockRoundNames[t],
+				Position:   position,
+				Question:   src.Question,
+				Type:       src.Type,
+				QID:        &selected[i].QID,
+				TimesAsked: src.TimesAsked,
+			})
+		}
+	}
+	return out
+}
+
+// ListMockInterviews returns the mock interviews of the current user, newest first
+func (h *Handler) ListMockInterviews(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	var q model.MockInterviewListQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "invalid query parameters")
+		return
+	}
+	if q.Limit <= 0 || q.Limit > 100 {
+		q.Limit = 20
+	}
+
+	items, total, err := h.Repository.ListMockInterviews(c.Request.Context(), claims.UserID, q.Limit, q.Offset)
+	if err != nil {
+		h.Logger.Error("list_mock_interviews: failed to fetch",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch mock interviews")
+		return
+	}
+
+	response.OKWithMeta(c, items, &response.Meta{
+		Total:   total,
+		HasNext: total > q.Offset+len(items),
+	})
+}
+
+// GetMockInterview returns a mock interview with its rounds, answers and feedback
+func (h *Handler) GetMockInterview(c *gin.Context) {
+	mockID, err := strconv.ParseInt(c.Param("mock_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid mock_id format")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	mock, ok := h.ownedMockInterview(c, claims.UserID, mockID)
+	if !ok {
+		return
+	}
+
+	response.OK(c, mock)
+}
+
+// AnswerMockQuestion stores an answer to a mock interview question and grades it with AI
+func (h *Handler) AnswerMockQuestion(c *gin.Context) {
+	mockID, err := strconv.ParseInt(c.Param("mock_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid mock_id format")
+		return
+	}
+	mqID, err := strconv.ParseInt(c.Param("mq_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid mq_id format")
+		return
+	}
+
+	var req model.AnswerMockQuestionReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "answer is required")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	mock, ok := h.ownedMockInterview(c, claims.UserID, mockID)
+	if !ok {
+		return
+	}
+
+	var question *model.MockInterviewQuestion
+	for i := range mock.Questions {
+		if mock.Questions[i].MQID == mqID {
+			question = &mock.Questions[i]
+			break
+		}
+	}
+	if question == nil {
+		response.NotFound(c, "question not found")
+		return
+	}
+
+	var feedback *model.MockFeedback
+	if h.GroqClient != nil {
+		fb, err := h.GroqClient.EvaluateAnswer(c.Request.Context(), mock.CompanyName, question.Question, question.Type, req.Answer)
+		if err != nil {
+			// the answer is still worth keeping, it can be graded by resubmitting
+			h.Logger.Warn("answer_mock_question: ai evaluation failed",
+				zap.Int64("mq_id", mqID),
+				zap.Error(err),
+			)
+		} else {
+			feedback = &model.MockFeedback{
+				Score:        fb.Score,
+				Summary:      fb.Summary,
+				Strengths:    fb.Strengths,
+				Improvements: fb.Improvements,
+			}
+		}
+	}
+
+	saved, err := h.Repository.SaveMockAnswer(c.Request.Context(), mockID, mqID, req.Answer, feedback)
+	if err != nil {
+		h.Logger.Error("answer_mock_question: failed to save answer",
+			zap.Int64("mq_id", mqID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to save answer")
+		return
+	}
+
+	response.OK(c, saved)
+}
+
+// DeleteMockInterview deletes a mock interview with its answers
+func (h *Handler) DeleteMockInterview(c *gin.Context) {
+	mockID, err := strconv.ParseInt(c.Param("mock_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid mock_id format")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	deleted, err := h.Repository.DeleteMockInterview(c.Request.Context(), claims.UserID, mockID)
+	if err != nil {
+		h.Logger.Error("delete_mock_interview: failed to delete",
+			zap.Int64("mock_id", mockID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to delete mock interview")
+		return
+	}
+	if !deleted {
+		response.NotFound(c, "mock interview not found")
+		return
+	}
+
+	response.Message(c, "mock interview deleted successfully")
+}
+
+// ownedMockInterview loads a mock interview of the user, writing the error response when it can't
+func (h *Handler) ownedMockInterview(c *gin.Context, userID uuid.UUID, mockID int64) (*model.MockInterview, bool) {
+	mock, err := h.Repository.GetMockInterview(c.Request.Context(), userID, mockID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			response.NotFound(c, "mock interview not found")
+			return nil, false
+		}
+		h.Logger.Error("get_mock_interview: failed to fetch",
+			zap.Int64("mock_id", mockID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch mock interview")
+		return nil, false
+	}
+	return mock, true
+}
+
+func valueOr(v *int, def int) int {
+	if v == nil {
+		return def
+	}
+	return *v
+}
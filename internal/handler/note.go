@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/abhishek622/interviewMin/internal/repository"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// GetQuestionNote returns the user's notes and answer for a question
+func (h *Handler) GetQuestionNote(c *gin.Context) {
+	questionID, err := strconv.ParseInt(c.Param("q_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid question_id format")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	if _, ok := h.ownedQuestion(c, questionID, claims.UserID); !ok {
+		return
+	}
+
+	note, err := h.Repository.GetQuestionNote(c.Request.Context(), questionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// no note yet, revision 0 lets the client save with base_revision 0
+			response.OK(c, model.QuestionNote{QID: questionID, Snippets: []model.CodeSnippet{}})
+			return
+		}
+		h.Logger.Error("get_question_note: failed to fetch",
+			zap.Int64("question_id", questionID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch note")
+		return
+	}
+
+	response.OK(c, note)
+}
+
+// SaveQuestionNote saves a new revision of the notes and answer for a question
+func (h *Handler) SaveQuestionNote(c *gin.Context) {
+	questionID, err := strconv.ParseInt(c.Param("q_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid question_id format")
+		return
+	}
+
+	var req model.SaveNoteReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	if _, ok := h.ownedQuestion(c, questionID, claims.UserID); !ok {
+		return
+	}
+
+	for i := range req.Snippets {
+		req.Snippets[i].Language = strings.ToLower(strings.TrimSpace(req.Snippets[i].Language))
+		req.Snippets[i].Title = strings.TrimSpace(req.Snippets[i].Title)
+	}
+
+	note, err := h.Repository.SaveQuestionNote(c.Request.Context(), claims.UserID, questionID, req.Content, req.Snippets, req.BaseRevision)
+	if err != nil {
+		if errors.Is(err, repository.ErrRevisionConflict) {
+			response.Conflict(c, "note was changed since it was loaded, reload and try again")
+			return
+		}
+		h.Logger.Error("save_question_note: failed to save",
+			zap.Int64("question_id", questionID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to save note")
+		return
+	}
+
+	h.Logger.Info("save_question_note: note saved",
+		zap.Int64("question_id", questionID),
+		zap.Int("revision", note.Revision),
+	)
+
+	response.OK(c, note)
+}
+
+// ListNoteRevisions returns the revision history of a question's notes, newest first
+func (h *Handler) ListNoteRevisions(c *gin.Context) {
+	questionID, err := strconv.ParseInt(c.Param("q_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid question_id format")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	if _, ok := h.ownedQuestion(c, questionID, claims.UserID); !ok {
+		return
+	}
+
+	revisions, err := h.Repository.ListNoteRevisions(c.Request.Context(), questionID)
+	if err != nil {
+		h.Logger.Error("list_note_revisions: failed to fetch",
+			zap.Int64("question_id", questionID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch note revisions")
+		return
+	}
+
+	response.OK(c, revisions)
+}
+
+// SearchQuestions searches question text and notes of the current user
+func (h *Handler) SearchQuestions(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	var q model.QuestionSearchQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "q is required")
+		return
+	}
+	q.Q = strings.TrimSpace(q.Q)
+	if q.Q == "" {
+		response.BadRequest(c, "q is required")
+		return
+	}
+	if q.Limit <= 0 || q.Limit > 100 {
+		q.Limit = 20
+	}
+
+	results, total, err := h.Repository.SearchQuestions(c.Request.Context(), claims.UserID, q.Q, q.Type, q.Limit, q.Offset)
+	if err != nil {
+		h.Logger.Error("search_questions: failed to search",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to search questions")
+		return
+	}
+
+	response.OKWithMeta(c, results, &response.Meta{
+		Total:   total,
+		HasNext: total > q.Offset+len(results),
+	})
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/abhishek622/interviewMin/internal/practice"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// PracticeDue returns today's practice queue: due reviews followed by a few new questions
+func (h *Handler) PracticeDue(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	var q model.PracticeDueQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "invalid query parameters")
+		return
+	}
+	if q.Limit <= 0 || q.Limit > 100 {
+		q.Limit = 20
+	}
+	q.NewLimit = max(0, min(q.NewLimit, q.Limit))
+
+	due, err := h.Repository.ListDuePractice(c.Request.Context(), claims.UserID, q.Limit, q.Type)
+	if err != nil {
+		h.Logger.Error("practice_due: failed to fetch due questions",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch practice queue")
+		return
+	}
+
+	items := due
+	if remaining := min(q.NewLimit, q.Limit-len(due)); remaining > 0 {
+		fresh, err := h.Repository.ListNewPractice(c.Request.Context(), claims.UserID, remaining, q.Type)
+		if err != nil {
+			h.Logger.Error("practice_due: failed to fetch new questions",
+				zap.String("user_id", claims.UserID.String()),
+				zap.Error(err),
+			)
+			response.InternalError(c, "failed to fetch practice queue")
+			return
+		}
+		items = append(items, fresh...)
+	}
+
+	if items == nil {
+		items = []model.PracticeItem{}
+	}
+
+	response.OKWithMeta(c, items, &response.Meta{Total: len(items)})
+}
+
+// ReviewQuestion records a practice result and reschedules the question
+func (h *Handler) ReviewQuestion(c *gin.Context) {
+	questionID, err := strconv.ParseInt(c.Param("q_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid question_id format")
+		return
+	}
+
+	var req model.ReviewQuestionReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	owned, err := h.Repository.QuestionBelongsToUser(c.Request.Context(), questionID, claims.UserID)
+	if err != nil || !owned {
+		response.NotFound(c, "question not found")
+		return
+	}
+
+	progress, err := h.Repository.GetQuestionProgress(c.Request.Context(), claims.UserID, questionID)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			h.Logger.Error("review_question: failed to fetch progress",
+				zap.Int64("question_id", questionID),
+				zap.Error(err),
+			)
+			response.InternalError(c, "failed to record review")
+			return
+		}
+		progress = &model.QuestionProgress{
+			UserID:     claims.UserID,
+			QID:        questionID,
+			EaseFactor: practice.DefaultEaseFactor,
+		}
+	}
+
+	now := time.Now().UTC()
+	state, dueAt := practice.Schedule(practice.State{
+		EaseFactor:   progress.EaseFactor,
+		IntervalDays: progress.IntervalDays,
+		Repetitions:  progress.Repetitions,
+	}, *req.Quality, now)
+
+	progress.EaseFactor = state.EaseFactor
+	progress.IntervalDays = state.IntervalDays
+	progress.Repetitions = state.Repetitions
+	progress.DueAt = &dueAt
+	progress.LastReviewedAt = &now
+	progress.Status = model.ProgressStatusAttempted
+	if practice.Passed(*req.Quality) {
+		progress.Status = model.ProgressStatusSolved
+	}
+	if req.Confidence != nil {
+		progress.Confidence = req.Confidence
+	}
+
+	if err := h.Repository.SaveReview(c.Request.Context(), progress, *req.Quality); err != nil {
+		h.Logger.Error("review_question: failed to save review",
+			zap.Int64("question_id", questionID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to record review")
+		return
+	}
+
+	response.OK(c, progress)
+}
+
+// UpdateQuestionProgress sets the status and confidence of a question by hand
+func (h *Handler) UpdateQuestionProgress(c *gin.Context) {
+	questionID, err := strconv.ParseInt(c.Param("q_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid question_id format")
+		return
+	}
+
+	var req model.UpdateProgressReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	owned, err := h.Repository.QuestionBelongsToUser(c.Request.Context(), questionID, claims.UserID)
+	if err != nil || !owned {
+		response.NotFound(c, "question not found")
+		return
+	}
+
+	if err := h.Repository.SetProgressStatus(c.Request.Context(), claims.UserID, questionID, req.Status, req.Confidence); err != nil {
+		h.Logger.Error("update_question_progress: failed to update",
+			zap.Int64("question_id", questionID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to update progress")
+		return
+	}
+
+	response.Message(c, "progress updated successfully")
+}
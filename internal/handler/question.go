@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"strconv"
 
 	"github.com/abhishek622/interviewMin/pkg/model"
 	"github.com/abhishek622/interviewMin/pkg/response"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -17,6 +21,12 @@ func (h *Handler) CreateQuestion(c *gin.Context) {
 		return
 	}
 
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
 	createdQuestion, err := h.Repository.CreateQuestion(c.Request.Context(), &model.Question{
 		InterviewID: req.InterviewID,
 		Question:    req.Question,
@@ -36,6 +46,8 @@ func (h *Handler) CreateQuestion(c *gin.Context) {
 		zap.Int64("question_id", createdQuestion.QID),
 	)
 
+	go h.enrichQuestions(context.Background(), claims.UserID, &req.InterviewID)
+
 	response.Created(c, createdQuestion)
 }
 
@@ -101,7 +113,12 @@ func (h *Handler) UpdateQuestion(c *gin.Context) {
 		return
 	}
 
-	if err := h.Repository.UpdateQuestion(c.Request.Context(), questionID, req.Question, req.Type); err != nil {
+	interviewID, err := h.Repository.UpdateQuestion(c.Request.Context(), questionID, req.Question, req.Type)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			response.NotFound(c, "question not found")
+			return
+		}
 		h.Logger.Error("update_question: failed to update",
 			zap.Int64("question_id", questionID),
 			zap.Error(err),
@@ -114,6 +131,10 @@ func (h *Handler) UpdateQuestion(c *gin.Context) {
 		zap.Int64("question_id", questionID),
 	)
 
+	if claims := h.GetClaimsFromContext(c); claims != nil {
+		go h.enrichQuestions(context.Background(), claims.UserID, &interviewID)
+	}
+
 	response.Message(c, "question updated successfully")
 }
 
@@ -146,3 +167,29 @@ func (h *Handler) DeleteQuestion(c *gin.Context) {
 
 	response.Message(c, "question deleted successfully")
 }
+
+// enrichQuestions links newly saved or edited questions to the question bank and to
+// LeetCode problems and refreshes embeddings. When interviewID is nil all of the user's
+// pending questions are processed.
+func (h *Handler) enrichQuestions(ctx context.Context, userID uuid.UUID, interviewID *int64) {
+	if _, err := h.linkQuestionBank(ctx, userID, interviewID); err != nil {
+		h.Logger.Warn("enrich_questions: failed to link question bank",
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+	}
+
+	if _, err := h.linkLeetcodeProblems(ctx, userID, interviewID); err != nil {
+		h.Logger.Warn("enrich_questions: failed to link leetcode problems",
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+	}
+
+	if _, err := h.indexEmbeddings(ctx, userID, embeddingIndexLimit); err != nil {
+		h.Logger.Warn("enrich_questions: failed to index embeddings",
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+	}
+}
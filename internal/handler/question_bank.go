@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abhishek622/interviewMin/pkg"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// questionMatchThreshold is the minimum similarity for two questions to be treated as the same
+const questionMatchThreshold = 0.8
+
+// QuestionBank returns canonical questions with frequency, companies and last asked date
+func (h *Handler) QuestionBank(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	var q model.QuestionBankQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "invalid query parameters")
+		return
+	}
+	if q.Limit <= 0 || q.Limit > 100 {
+		q.Limit = 20
+	}
+	var companyID *uuid.UUID
+	if q.CompanyID != "" {
+		id, err := uuid.Parse(q.CompanyID)
+		if err != nil {
+			response.BadRequest(c, "invalid company_id format")
+			return
+		}
+		companyID = &id
+	}
+
+	items, total, err := h.Repository.QuestionBank(c.Request.Context(), claims.UserID, q.Type, companyID, q.Limit, q.Offset)
+	if err != nil {
+		h.Logger.Error("question_bank: failed to fetch",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch question bank")
+		return
+	}
+
+	response.OKWithMeta(c, items, &response.Meta{
+		Total:   total,
+		HasNext: total > q.Offset+len(items),
+	})
+}
+
+// RelinkQuestionBank links every question of the current user that has no canonical question yet
+func (h *Handler) RelinkQuestionBank(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	linked, err := h.linkQuestionBank(c.Request.Context(), claims.UserID, nil)
+	if err != nil {
+		h.Logger.Error("relink_question_bank: failed to link",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to link questions")
+		return
+	}
+
+	response.OK(c, gin.H{"linked": linked})
+}
+
+// linkQuestionBank links unlinked questions to an existing canonical question by
+// normalized text or fuzzy match, creating a new canonical question when nothing matches.
+// When interviewID is nil all of the user's unlinked questions are processed.
+func (h *Handler) linkQuestionBank(ctx context.Context, userID uuid.UUID, interviewID *int64) (int, error) {
+	questions, err := h.Repository.ListUnlinkedQuestions(ctx, userID, interviewID)
+	if err != nil {
+		return 0, err
+	}
+	if len(questions) == 0 {
+		return 0, nil
+	}
+
+	candidates, err := h.Repository.ListCanonicalQuestions(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	linked := 0
+	for _, q := range questions {
+		normalized := pkg.NormalizeText(q.Question)
+		if normalized == "" {
+			continue
+		}
+
+		cq := matchCanonical(normalized, q.Type, candidates)
+		if cq == nil {
+			cq, err = h.Repository.CreateCanonicalQuestion(ctx, &model.CanonicalQuestion{
+				UserID:     userID,
+				Question:   q.Question,
+				Normalized: normalized,
+				Type:       q.Type,
+			})
+			if err != nil {
+				return linked, fmt.Errorf("question %d: %w", q.QID, err)
+			}
+			candidates = append(candidates, *cq)
+		}
+
+		if err := h.Repository.SetQuestionCanonical(ctx, q.QID, cq.CQID); err != nil {
+			return linked, err
+		}
+		linked++
+	}
+
+	return linked, nil
+}
+
+// matchCanonical returns the candidate with the same normalized text, or the most similar
+// candidate of the same type above questionMatchThreshold
+func matchCanonical(normalized, qType string, candidates []model.CanonicalQuestion) *model.CanonicalQuestion {
+	var best *model.CanonicalQuestion
+	bestScore := questionMatchThreshold
+
+	for i := range candidates {
+		cand := &candidates[i]
+		if cand.Normalized == normalized {
+			return cand
+		}
+		if cand.Type != qType {
+			continue
+		}
+		if score := pkg.Similarity(normalized, cand.Normalized); score >= bestScore {
+			best, bestScore = cand, score
+		}
+	}
+
+	return best
+}
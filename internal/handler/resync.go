@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhishek622/interviewMin/internal/fetcher"
+	"github.com/abhishek622/interviewMin/pkg"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// resyncMatchThreshold is how similar a re-extracted question must be to an existing one
+// to count as the same question
+const resyncMatchThreshold = 0.85
+
+var (
+	errNotImported = errors.New("interview was not imported from a URL")
+	errResyncFetch = errors.New("failed to fetch content from URL")
+)
+
+// ResyncInterview re-fetches an imported interview from its source URL and, when the
+// content changed, re-extracts its questions. New questions are added, existing ones are
+// never removed since extraction output varies between runs.
+func (h *Handler) ResyncInterview(c *gin.Context) {
+	interviewID, err := strconv.ParseInt(c.Param("interview_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid interview_id format")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	interview, err := h.Repository.GetInterviewByID(c.Request.Context(), interviewID)
+	if err != nil || interview.UserID != claims.UserID {
+		if err == nil || errors.Is(err, pgx.ErrNoRows) {
+			response.NotFound(c, "interview not found")
+			return
+		}
+		h.Logger.Error("resync_interview: failed to fetch interview",
+			zap.Int64("interview_id", interviewID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch interview")
+		return
+	}
+
+	res, err := h.resyncInterview(c.Request.Context(), interview)
+	if err != nil {
+		switch {
+		case errors.Is(err, errNotImported):
+			response.BadRequest(c, err.Error())
+		case errors.Is(err, errResyncFetch):
+			response.BadRequest(c, errResyncFetch.Error())
+		default:
+			h.Logger.Error("resync_interview: failed to resync",
+				zap.Int64("interview_id", interviewID),
+				zap.Error(err),
+			)
+			response.InternalError(c, "failed to resync interview")
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// resyncInterview fetches an interview's source again and adds re-extracted questions
+// that match none of the existing ones
+func (h *Handler) resyncInterview(ctx context.Context, interview *model.InterviewRes) (*model.ResyncRes, error) {
+	if interview.SourceURL == nil {
+		return nil, errNotImported
+	}
+
+	fetched, err := fetcher.Fetch(fetcher.WithoutCache(ctx), *interview.SourceURL, interview.Source)
+	if err != nil {
+		h.Logger.Warn("resync_interview: fetch failed",
+			zap.Int64("interview_id", interview.InterviewID),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("%w: %v", errResyncFetch, err)
+	}
+
+	// interviews imported before snapshots were kept get one now
+	h.saveSnapshot(ctx, &interview.InterviewID, fetched.Raw)
+
+	res := &model.ResyncRes{InterviewID: interview.InterviewID, FetchedAt: time.Now().UTC()}
+	hash := fetched.Hash()
+
+	if interview.ContentHash != nil && *interview.ContentHash == hash {
+		if err := h.Repository.UpdateInterview(ctx, interview.InterviewID, map[string]interface{}{"fetched_at": res.FetchedAt}); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+	res.Changed = true
+
+	title := strings.TrimSpace(fetched.Title)
+	content := strings.TrimSpace(fetched.Content)
+	extracted, err := h.GroqClient.InterviewQuestions(ctx, fmt.Sprintf("%s\n\n%s", title, content))
+	if err != nil {
+		// keep the stored hash so the next resync tries again
+		return nil, fmt.Errorf("extract questions: %w", err)
+	}
+
+	existing, err := h.Repository.ListResyncQuestions(ctx, interview.InterviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make([]string, len(existing))
+	for i, e := range existing {
+		known[i] = pkg.NormalizeText(e.Question)
+	}
+	var add []model.Question
+	for _, q := range *extracted {
+		norm := pkg.NormalizeText(q.Question)
+		found := false
+		// text without letters or digits matches nothing, it is never taken for a duplicate
+		for _, k := range known {
+			if norm != "" && k != "" && pkg.Similarity(norm, k) >= resyncMatchThreshold {
+				found = true
+				break
+			}
+		}
+		if !found {
+			add = append(add, model.Question{InterviewID: interview.InterviewID, Question: q.Question, Type: q.Type})
+			// a question extracted twice in one run is added once
+			known = append(known, norm)
+		}
+	}
+	res.Added = len(add)
+
+	if err := h.Repository.AddResyncQuestions(ctx, interview.InterviewID, add); err != nil {
+		return nil, err
+	}
+
+	metadata := interview.Metadata
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	if t, _ := metadata["title"].(string); t == "" {
+		metadata["title"] = title
+	}
+	metadata["full_experience"] = content
+
+	updates := map[string]interface{}{
+		"raw_input":      fmt.Sprintf("%s\n\n%s", title, content),
+		"metadata":       metadata,
+		"language":       pkg.DetectLanguage(content),
+		"content_hash":   hash,
+		"fetched_at":     res.FetchedAt,
+		"process_status": model.ProcessStatusSuccess,
+		"process_error":  nil,
+	}
+	if fetched.PublishedAt != nil {
+		updates["source_published_at"] = fetched.PublishedAt
+	}
+	if err := h.Repository.UpdateInterview(ctx, interview.InterviewID, updates); err != nil {
+		return nil, err
+	}
+
+	if len(add) > 0 {
+		h.enrichQuestions(ctx, interview.UserID, &interview.InterviewID)
+	}
+
+	h.Logger.Info("resync_interview: interview resynced",
+		zap.Int64("interview_id", interview.InterviewID),
+		zap.Int("added", res.Added),
+	)
+	return res, nil
+}
+
+// RunResyncScheduler periodically resyncs recent imports until ctx is cancelled
+func (h *Handler) RunResyncScheduler(ctx context.Context) {
+	cfg := h.Config.Resync
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		h.resyncRecentImports(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *Handler) resyncRecentImports(ctx context.Context) {
+	cfg := h.Config.Resync
+	now := time.Now()
+
+	candidates, err := h.Repository.ListResyncCandidates(ctx, now.Add(-cfg.MaxAge), now.Add(-cfg.StaleAfter), cfg.BatchSize)
+	if err != nil {
+		h.Logger.Error("resync_scheduler: failed to list candidates", zap.Error(err))
+		return
+	}
+
+	for _, candidate := range candidates {
+		if ctx.Err() != nil {
+			return
+		}
+		interview, err := h.Repository.GetInterviewByID(ctx, candidate.InterviewID)
+		if err != nil {
+			h.Logger.Warn("resync_scheduler: failed to fetch interview",
+				zap.Int64("interview_id", candidate.InterviewID),
+				zap.Error(err),
+			)
+			continue
+		}
+		if _, err := h.resyncInterview(ctx, interview); err != nil {
+			h.Logger.Warn("resync_scheduler: resync failed",
+				zap.Int64("interview_id", candidate.InterviewID),
+				zap.Error(err),
+			)
+			if errors.Is(err, errResyncFetch) {
+				// do not retry a dead link on every run
+				_ = h.Repository.UpdateInterview(ctx, candidate.InterviewID, map[string]interface{}{"fetched_at": time.Now().UTC()})
+			}
+		}
+	}
+}
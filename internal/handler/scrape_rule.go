@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/abhishek622/interviewMin/internal/fetcher"
+	"github.com/abhishek622/interviewMin/internal/repository"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// LoadScrapeRules hands the enabled rules of the database, then those of the rules file,
+// to the fetcher
+func (h *Handler) LoadScrapeRules(ctx context.Context) error {
+	stored, err := h.Repository.ListScrapeRules(ctx, true)
+	if err != nil {
+		return err
+	}
+	if h.Config.Scrape.RulesFile != "" {
+		fromFile, err := fetcher.LoadScrapeRulesFile(h.Config.Scrape.RulesFile)
+		if err != nil {
+			return err
+		}
+		stored = append(stored, fromFile...)
+	}
+
+	compiled := make([]*fetcher.Rule, 0, len(stored))
+	for _, r := range stored {
+		rule, err := fetcher.CompileRule(r)
+		if err != nil {
+			return err
+		}
+		compiled = append(compiled, rule)
+	}
+	fetcher.SetScrapeRules(compiled)
+
+	h.Logger.Info("scrape_rules: rules loaded", zap.Int("count", len(compiled)))
+	return nil
+}
+
+// reloadScrapeRules applies a rule change right away. A failure leaves the previous rules
+// in use and is reported to the caller, the change itself is already stored.
+func (h *Handler) reloadScrapeRules(c *gin.Context, change string) bool {
+	if err := h.LoadScrapeRules(c.Request.Context()); err != nil {
+		h.Logger.Error("scrape_rules: failed to reload rules", zap.Error(err))
+		response.InternalError(c, fmt.Sprintf("scrape rule %s but the rules could not be reloaded, the previous rules stay in use: %v", change, err))
+		return false
+	}
+	return true
+}
+
+// ListScrapeRules returns the rules stored in the database (admin only)
+func (h *Handler) ListScrapeRules(c *gin.Context) {
+	rules, err := h.Repository.ListScrapeRules(c.Request.Context(), false)
+	if err != nil {
+		h.Logger.Error("list_scrape_rules: failed to fetch", zap.Error(err))
+		response.InternalError(c, "failed to fetch scrape rules")
+		return
+	}
+
+	response.OK(c, rules)
+}
+
+// bindScrapeRule reads and validates a rule from the request body
+func bindScrapeRule(c *gin.Context) (*model.ScrapeRule, bool) {
+	var req model.SaveScrapeRuleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "name, host_pattern and content_selector are required")
+		return nil, false
+	}
+
+	rule := req.ScrapeRule
+	rule.Name = strings.TrimSpace(rule.Name)
+	rule.Enabled = req.Enabled == nil || *req.Enabled
+	if rule.RemoveSelectors == nil {
+		rule.RemoveSelectors = []string{}
+	}
+
+	compiled, err := fetcher.CompileRule(rule)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return nil, false
+	}
+	return &compiled.ScrapeRule, true
+}
+
+// CreateScrapeRule stores a new scraping rule and starts using it (admin only)
+func (h *Handler) CreateScrapeRule(c *gin.Context) {
+	rule, ok := bindScrapeRule(c)
+	if !ok {
+		return
+	}
+
+	if err := h.Repository.CreateScrapeRule(c.Request.Context(), rule); err != nil {
+		if errors.Is(err, repository.ErrScrapeRuleExists) {
+			response.Conflict(c, "a rule with this name already exists")
+			return
+		}
+		h.Logger.Error("create_scrape_rule: failed to create", zap.Error(err))
+		response.InternalError(c, "failed to create scrape rule")
+		return
+	}
+	if !h.reloadScrapeRules(c, "created") {
+		return
+	}
+
+	response.Created(c, rule)
+}
+
+// UpdateScrapeRule replaces a scraping rule (admin only)
+func (h *Handler) UpdateScrapeRule(c *gin.Context) {
+	ruleID, err := strconv.ParseInt(c.Param("rule_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid rule_id format")
+		return
+	}
+
+	rule, ok := bindScrapeRule(c)
+	if !ok {
+		return
+	}
+	rule.RuleID = ruleID
+
+	updated, err := h.Repository.UpdateScrapeRule(c.Request.Context(), rule)
+	if err != nil {
+		if errors.Is(err, repository.ErrScrapeRuleExists) {
+			response.Conflict(c, "a rule with this name already exists")
+			return
+		}
+		h.Logger.Error("update_scrape_rule: failed to update",
+			zap.Int64("rule_id", ruleID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to update scrape rule")
+		return
+	}
+	if !updated {
+		response.NotFound(c, "scrape rule not found")
+		return
+	}
+	if !h.reloadScrapeRules(c, "updated") {
+		return
+	}
+
+	response.OK(c, rule)
+}
+
+// DeleteScrapeRule removes a scraping rule (admin only)
+func (h *Handler) DeleteScrapeRule(c *gin.Context) {
+	ruleID, err := strconv.ParseInt(c.Param("rule_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid rule_id format")
+		return
+	}
+
+	deleted, err := h.Repository.DeleteScrapeRule(c.Request.Context(), ruleID)
+	if err != nil {
+		h.Logger.Error("delete_scrape_rule: failed to delete",
+			zap.Int64("rule_id", ruleID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to delete scrape rule")
+		return
+	}
+	if !deleted {
+		response.NotFound(c, "scrape rule not found")
+		return
+	}
+	if !h.reloadScrapeRules(c, "deleted") {
+		return
+	}
+
+	response.Message(c, "scrape rule deleted successfully")
+}
+
+// TestScrapeRule runs a stored or unsaved rule against a URL and previews what an import
+// would extract (admin only)
+func (h *Handler) TestScrapeRule(c *gin.Context) {
+	var req model.TestScrapeRuleReq
+	if err := c.ShouldBindJSON(&req); err != nil || (req.RuleID == nil) == (req.Rule == nil) {
+		response.BadRequest(c, "url and either rule_id or rule are required")
+		return
+	}
+
+	u, err := url.Parse(strings.TrimSpace(req.URL))
+	if err != nil {
+		response.BadRequest(c, "invalid url")
+		return
+	}
+
+	rule := req.Rule
+	if req.RuleID != nil {
+		rule, err = h.Repository.GetScrapeRule(c.Request.Context(), *req.RuleID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				response.NotFound(c, "scrape rule not found")
+				return
+			}
+			h.Logger.Error("test_scrape_rule: failed to fetch rule",
+				zap.Int64("rule_id", *req.RuleID),
+				zap.Error(err),
+			)
+			response.InternalError(c, "failed to fetch scrape rule")
+			return
+		}
+	}
+
+	compiled, err := fetcher.CompileRule(*rule)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	res, err := fetcher.GetWithRule(c.Request.Context(), compiled, u.String())
+	if err != nil {
+		response.BadRequest(c, fmt.Sprintf("rule failed: %v", err))
+		return
+	}
+
+	response.OK(c, model.ScrapePreview{
+		Rule:        compiled.Name,
+		Matches:     compiled.Matches(u),
+		URL:         res.URL,
+		Title:       res.Title,
+		Content:     res.Content,
+		PublishedAt: res.PublishedAt,
+		Length:      len(res.Content),
+	})
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Search runs a full-text search across all interviews, questions and companies of the current user
+func (h *Handler) Search(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	var q model.SearchQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "q is required")
+		return
+	}
+	q.Q = strings.TrimSpace(q.Q)
+	if q.Q == "" {
+		response.BadRequest(c, "q is required")
+		return
+	}
+	if q.Limit <= 0 || q.Limit > 100 {
+		q.Limit = 20
+	}
+
+	var types []model.SearchResultType
+	if q.Types != nil {
+		for _, t := range *q.Types {
+			switch t {
+			case model.SearchResultInterview, model.SearchResultQuestion, model.SearchResultCompany:
+				types = append(types, t)
+			default:
+				response.BadRequest(c, "type must be one of interview, question, company")
+				return
+			}
+		}
+	}
+
+	results, facets, err := h.Repository.GlobalSearch(c.Request.Context(), claims.UserID, q.Q, types, q.Limit, q.Offset)
+	if err != nil {
+		h.Logger.Error("search: failed to search",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to search")
+		return
+	}
+
+	total := 0
+	for t, count := range facets {
+		if len(types) == 0 || slices.Contains(types, t) {
+			total += count
+		}
+	}
+
+	response.OKWithMeta(c, model.SearchRes{Results: results, Facets: facets}, &response.Meta{
+		Total:   total,
+		HasNext: total > q.Offset+len(results),
+	})
+}
+
+// languageDetectionBatch is how many interviews DetectInterviewLanguages updates at a time
+const languageDetectionBatch = 200
+
+// DetectInterviewLanguages detects the language of interviews stored before languages were
+// detected, their search documents are reindexed by the triggers as they are updated
+func (h *Handler) DetectInterviewLanguages(ctx context.Context) {
+	total := 0
+	for ctx.Err() == nil {
+		n, err := h.Repository.DetectPendingLanguages(ctx, languageDetectionBatch)
+		total += n
+		if err != nil {
+			h.Logger.Error("detect_languages: failed to detect interview languages", zap.Error(err))
+			return
+		}
+		if n < languageDetectionBatch {
+			break
+		}
+	}
+	if total > 0 {
+		h.Logger.Info("detect_languages: interview languages detected", zap.Int("interviews", total))
+	}
+}
@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/abhishek622/interviewMin/pkg"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// embeddingBatchSize is the number of texts sent to the provider at once
+	embeddingBatchSize = 32
+	// embeddingIndexLimit caps how many documents one indexing pass embeds
+	embeddingIndexLimit = 256
+	// embeddingMaxChars truncates long experiences before embedding
+	embeddingMaxChars = 8000
+	// semanticCandidates is how many hits each ranking contributes to hybrid search
+	semanticCandidates = 100
+	// rrfK dampens the weight of top ranks in reciprocal rank fusion
+	rrfK = 60
+)
+
+// indexingUsers holds the users with a background indexing pass running
+var indexingUsers sync.Map
+
+// indexEmbeddingsInBackground starts an indexing pass for the user unless one is running
+func (h *Handler) indexEmbeddingsInBackground(userID uuid.UUID) {
+	if h.Embedder == nil {
+		return
+	}
+	if _, running := indexingUsers.LoadOrStore(userID, struct{}{}); running {
+		return
+	}
+	go func() {
+		defer indexingUsers.Delete(userID)
+		if _, err := h.indexEmbeddings(context.Background(), userID, embeddingIndexLimit); err != nil {
+			h.Logger.Warn("index_embeddings: background pass failed",
+				zap.String("user_id", userID.String()),
+				zap.Error(err),
+			)
+		}
+	}()
+}
+
+// indexEmbeddings embeds the user's interviews and questions that have no embedding yet
+// or changed since they were embedded, and returns how many were embedded
+func (h *Handler) indexEmbeddings(ctx context.Context, userID uuid.UUID, limit int) (int, error) {
+	if h.Embedder == nil {
+		return 0, nil
+	}
+
+	provider := h.Embedder.Name()
+	indexed := 0
+	for indexed < limit {
+		docs, err := h.Repository.ListStaleEmbeddings(ctx, userID, provider, min(embeddingBatchSize, limit-indexed))
+		if err != nil {
+			return indexed, err
+		}
+		if len(docs) == 0 {
+			break
+		}
+
+		texts := make([]string, len(docs))
+		for i, d := range docs {
+			texts[i] = pkg.Truncate(d.Content, embeddingMaxChars)
+		}
+
+		vectors, err := h.Embedder.Embed(ctx, texts)
+		if err != nil {
+			return indexed, err
+		}
+		if err := h.Repository.SaveEmbeddings(ctx, userID, provider, docs, vectors); err != nil {
+			return indexed, err
+		}
+		indexed += len(docs)
+	}
+	return indexed, nil
+}
+
+// SemanticSearch finds interviews and questions similar in meaning to the query. In hybrid
+// mode the embedding ranking is fused with the full-text ranking.
+func (h *Handler) SemanticSearch(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	var q model.SemanticSearchQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "q is required")
+		return
+	}
+	q.Q = strings.TrimSpace(q.Q)
+	if q.Q == "" {
+		response.BadRequest(c, "q is required")
+		return
+	}
+	if q.Mode != model.SemanticSearchModeSemantic && q.Mode != model.SemanticSearchModeHybrid {
+		response.BadRequest(c, "mode must be one of semantic, hybrid")
+		return
+	}
+	if q.Limit <= 0 || q.Limit > 100 {
+		q.Limit = 20
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+
+	types := []model.SearchResultType{model.SearchResultInterview, model.SearchResultQuestion}
+	if q.Types != nil && len(*q.Types) > 0 {
+		types = types[:0]
+		for _, t := range *q.Types {
+			if t != model.SearchResultInterview && t != model.SearchResultQuestion {
+				response.BadRequest(c, "type must be one of interview, question")
+				return
+			}
+			types = append(types, t)
+		}
+	}
+
+	if h.Embedder == nil {
+		response.InternalError(c, "semantic search is not configured")
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// documents are indexed when they are saved, this picks up whatever a failed
+	// pass left behind without making the search wait for it
+	h.indexEmbeddingsInBackground(claims.UserID)
+
+	vectors, err := h.Embedder.Embed(ctx, []string{q.Q})
+	if err != nil || len(vectors) != 1 {
+		h.Logger.Error("semantic_search: failed to embed query",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to search")
+		return
+	}
+
+	hits, err := h.Repository.SemanticSearch(ctx, claims.UserID, h.Embedder.Name(), vectors[0], types, semanticCandidates)
+	if err != nil {
+		h.Logger.Error("semantic_search: failed to rank embeddings",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to search")
+		return
+	}
+
+	type key struct {
+		t  model.SearchResultType
+		id int64
+	}
+	fused := map[key]*model.SemanticSearchResult{}
+	var order []key
+
+	for rank, hit := range hits {
+		if hit.Similarity <= 0 {
+			continue
+		}
+		k := key{hit.Type, hit.ID}
+		similarity := hit.Similarity
+		res := &model.SemanticSearchResult{Similarity: &similarity}
+		res.Type = hit.Type
+		if q.Mode == model.SemanticSearchModeHybrid {
+			res.Score = 1 / float64(rrfK+rank+1)
+		} else {
+			res.Score = float64(hit.Similarity)
+		}
+		fused[k] = res
+		order = append(order, k)
+	}
+
+	if q.Mode == model.SemanticSearchModeHybrid {
+		keyword, _, err := h.Repository.GlobalSearch(ctx, claims.UserID, q.Q, types, semanticCandidates, 0)
+		if err != nil {
+			h.Logger.Error("semantic_search: failed to run keyword search",
+				zap.String("user_id", claims.UserID.String()),
+				zap.Error(err),
+			)
+			response.InternalError(c, "failed to search")
+			return
+		}
+		for rank, kw := range keyword {
+			k := key{kw.Type, *kw.InterviewID}
+			if kw.Type == model.SearchResultQuestion {
+				k.id = *kw.QID
+			}
+			res, ok := fused[k]
+			if !ok {
+				res = &model.SemanticSearchResult{}
+				fused[k] = res
+				order = append(order, k)
+			}
+			res.SearchResult = kw
+			res.Score += 1 / float64(rrfK+rank+1)
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool { return fused[order[i]].Score > fused[order[j]].Score })
+	total := len(order)
+	if q.Offset >= len(order) {
+		order = nil
+	} else {
+		order = order[q.Offset:min(q.Offset+q.Limit, len(order))]
+	}
+
+	// semantic-only hits still need their display fields
+	var interviewIDs, qIDs []int64
+	for _, k := range order {
+		if fused[k].Title != "" {
+			continue
+		}
+		if k.t == model.SearchResultInterview {
+			interviewIDs = append(interviewIDs, k.id)
+		} else {
+			qIDs = append(qIDs, k.id)
+		}
+	}
+	interviews, questions, err := h.Repository.SearchResultDetails(ctx, claims.UserID, interviewIDs, qIDs)
+	if err != nil {
+		h.Logger.Error("semantic_search: failed to load result details",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to search")
+		return
+	}
+
+	results := make([]model.SemanticSearchResult, 0, len(order))
+	for _, k := range order {
+		res := fused[k]
+		if res.Title == "" {
+			details, ok := interviews[k.id]
+			if k.t == model.SearchResultQuestion {
+				details, ok = questions[k.id]
+			}
+			if !ok {
+				continue
+			}
+			res.SearchResult = details
+		}
+		results = append(results, *res)
+	}
+
+	response.OKWithMeta(c, results, &response.Meta{
+		Total:   total,
+		HasNext: total > q.Offset+len(order),
+	})
+}
+
+// ReindexEmbeddings embeds every interview and question of the current user that is missing
+// an embedding or changed since it was embedded
+func (h *Handler) ReindexEmbeddings(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	indexed, err := h.indexEmbeddings(c.Request.Context(), claims.UserID, embeddingIndexLimit*8)
+	if err != nil {
+		h.Logger.Error("reindex_embeddings: failed to index",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Int("indexed", indexed),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to index embeddings")
+		return
+	}
+
+	response.OK(c, gin.H{"indexed": indexed})
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/abhishek622/interviewMin/internal/archive"
+	"github.com/abhishek622/interviewMin/internal/fetcher"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// saveSnapshot archives the raw response an interview was extracted from. Failures are
+// only logged, the import itself succeeded.
+func (h *Handler) saveSnapshot(ctx context.Context, interviewID *int64, raw *fetcher.RawResponse) {
+	if interviewID == nil || raw == nil || h.Archive == nil {
+		return
+	}
+	log := h.Logger.With(zap.Int64("interview_id", *interviewID))
+
+	key := archive.ContentKey(raw.Body)
+	if err := h.Archive.Put(ctx, key, &archive.Blob{ContentType: raw.ContentType, Data: raw.Body}); err != nil {
+		log.Error("save_snapshot: failed to store blob", zap.String("archive", h.Archive.Name()), zap.Error(err))
+		return
+	}
+
+	err := h.Repository.CreateInterviewSnapshot(ctx, &model.InterviewSnapshot{
+		InterviewID: *interviewID,
+		BlobKey:     key,
+		ContentType: raw.ContentType,
+		Size:        len(raw.Body),
+		SourceURL:   raw.URL,
+		FetchedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		log.Error("save_snapshot: failed to record snapshot", zap.Error(err))
+	}
+}
+
+// RunCacheSweeper deletes expired fetch cache entries from the archive every configured
+// interval until ctx is cancelled, the fetcher only skips them on read
+func (h *Handler) RunCacheSweeper(ctx context.Context) {
+	runEvery(ctx, h.Config.Archive.CacheSweepInterval, true, h.sweepFetchCache)
+}
+
+func (h *Handler) sweepFetchCache(ctx context.Context) {
+	deleted, err := h.Archive.DeleteBefore(ctx, archive.CachePrefix, time.Now().Add(-h.Config.Archive.CacheTTL))
+	if err != nil {
+		h.Logger.Error("cache_sweeper: failed to delete expired entries", zap.String("archive", h.Archive.Name()), zap.Error(err))
+		return
+	}
+	if deleted > 0 {
+		h.Logger.Info("cache_sweeper: expired entries deleted", zap.Int64("entries", deleted))
+	}
+}
+
+// GetInterviewSnapshot returns the raw HTML or JSON an imported interview was extracted from
+func (h *Handler) GetInterviewSnapshot(c *gin.Context) {
+	interviewID, err := strconv.ParseInt(c.Param("interview_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid interview_id format")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	snapshot, err := h.Repository.GetInterviewSnapshot(c.Request.Context(), claims.UserID, interviewID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			response.NotFound(c, "snapshot not found")
+			return
+		}
+		h.Logger.Error("get_interview_snapshot: failed to fetch",
+			zap.Int64("interview_id", interviewID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch snapshot")
+		return
+	}
+
+	blob, err := h.Archive.Get(c.Request.Context(), snapshot.BlobKey)
+	if err != nil {
+		if errors.Is(err, archive.ErrNotFound) {
+			response.NotFound(c, "snapshot not found")
+			return
+		}
+		h.Logger.Error("get_interview_snapshot: failed to read blob",
+			zap.Int64("interview_id", interviewID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch snapshot")
+		return
+	}
+
+	contentType := snapshot.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	// the page is third party content, keep it from running scripts on our origin
+	c.Header("Content-Security-Policy", "sandbox")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("X-Snapshot-Source-URL", snapshot.SourceURL)
+	c.Header("X-Snapshot-Fetched-At", snapshot.FetchedAt.Format(time.RFC3339))
+	c.Data(http.StatusOK, contentType, blob.Data)
+}
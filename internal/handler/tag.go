@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/abhishek622/interviewMin/internal/repository"
+	"github.com/abhishek622/interviewMin/pkg"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// ListTags returns all tags for the current user with usage counts
+func (h *Handler) ListTags(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	tags, err := h.Repository.ListTags(c.Request.Context(), claims.UserID)
+	if err != nil {
+		h.Logger.Error("list_tags: failed to fetch tags",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch tags")
+		return
+	}
+
+	if tags == nil {
+		tags = []model.TagRes{}
+	}
+
+	response.OK(c, tags)
+}
+
+// CreateTag creates a new tag for the current user
+func (h *Handler) CreateTag(c *gin.Context) {
+	var req model.CreateTagReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		response.BadRequest(c, "name is required")
+		return
+	}
+
+	tag, err := h.Repository.CreateTag(c.Request.Context(), &model.Tag{
+		UserID: claims.UserID,
+		Name:   name,
+		Slug:   pkg.GenerateSlug(name),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrTagExists) {
+			response.Conflict(c, "tag already exists")
+			return
+		}
+		h.Logger.Error("create_tag: failed to create",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to create tag")
+		return
+	}
+
+	response.Created(c, tag)
+}
+
+// RenameTag renames an existing tag
+func (h *Handler) RenameTag(c *gin.Context) {
+	tagID, err := strconv.ParseInt(c.Param("tag_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid tag_id format")
+		return
+	}
+
+	var req model.RenameTagReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		response.BadRequest(c, "name is required")
+		return
+	}
+
+	if err := h.Repository.RenameTag(c.Request.Context(), claims.UserID, tagID, name, pkg.GenerateSlug(name)); err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			response.NotFound(c, "tag not found")
+		case errors.Is(err, repository.ErrTagExists):
+			response.Conflict(c, "a tag with this name already exists, merge them instead")
+		default:
+			h.Logger.Error("rename_tag: failed to rename",
+				zap.Int64("tag_id", tagID),
+				zap.Error(err),
+			)
+			response.InternalError(c, "failed to rename tag")
+		}
+		return
+	}
+
+	response.Message(c, "tag renamed successfully")
+}
+
+// DeleteTag deletes a tag and detaches it from all interviews and questions
+func (h *Handler) DeleteTag(c *gin.Context) {
+	tagID, err := strconv.ParseInt(c.Param("tag_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid tag_id format")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	if err := h.Repository.DeleteTag(c.Request.Context(), claims.UserID, tagID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			response.NotFound(c, "tag not found")
+			return
+		}
+		h.Logger.Error("delete_tag: failed to delete",
+			zap.Int64("tag_id", tagID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to delete tag")
+		return
+	}
+
+	response.Message(c, "tag deleted successfully")
+}
+
+// MergeTags merges one or more source tags into a target tag
+func (h *Handler) MergeTags(c *gin.Context) {
+	var req model.MergeTagsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	slices.Sort(req.SourceTagIDs)
+	req.SourceTagIDs = slices.Compact(req.SourceTagIDs)
+	if slices.Contains(req.SourceTagIDs, req.TargetTagID) {
+		response.BadRequest(c, "target tag cannot be one of the source tags")
+		return
+	}
+
+	if err := h.Repository.MergeTags(c.Request.Context(), claims.UserID, req.SourceTagIDs, req.TargetTagID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			response.NotFound(c, "one or more tags not found")
+			return
+		}
+		h.Logger.Error("merge_tags: failed to merge",
+			zap.Int64("target_tag_id", req.TargetTagID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to merge tags")
+		return
+	}
+
+	h.Logger.Info("merge_tags: tags merged",
+		zap.Int64("target_tag_id", req.TargetTagID),
+		zap.Int("count", len(req.SourceTagIDs)),
+	)
+
+	response.Message(c, "tags merged successfully")
+}
+
+// SetInterviewTags replaces the tags attached to an interview
+func (h *Handler) SetInterviewTags(c *gin.Context) {
+	interviewID, err := strconv.ParseInt(c.Param("interview_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid interview_id format")
+		return
+	}
+
+	var req model.SetTagsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	interview, err := h.Repository.GetInterviewByID(c.Request.Context(), interviewID)
+	if err != nil || interview.UserID != claims.UserID {
+		response.NotFound(c, "interview not found")
+		return
+	}
+
+	if err := h.Repository.SetInterviewTags(c.Request.Context(), claims.UserID, interviewID, req.TagIDs); err != nil {
+		h.Logger.Error("set_interview_tags: failed to update",
+			zap.Int64("interview_id", interviewID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to update interview tags")
+		return
+	}
+
+	response.Message(c, "interview tags updated successfully")
+}
+
+// SetQuestionTags replaces the tags attached to a question
+func (h *Handler) SetQuestionTags(c *gin.Context) {
+	questionID, err := strconv.ParseInt(c.Param("q_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid question_id format")
+		return
+	}
+
+	var req model.SetTagsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	owned, err := h.Repository.QuestionBelongsToUser(c.Request.Context(), questionID, claims.UserID)
+	if err != nil || !owned {
+		response.NotFound(c, "question not found")
+		return
+	}
+
+	if err := h.Repository.SetQuestionTags(c.Request.Context(), claims.UserID, questionID, req.TagIDs); err != nil {
+		h.Logger.Error("set_question_tags: failed to update",
+			zap.Int64("question_id", questionID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to update question tags")
+		return
+	}
+
+	response.Message(c, "question tags updated successfully")
+}
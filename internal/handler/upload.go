@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/abhishek622/interviewMin/internal/archive"
+	"github.com/abhishek622/interviewMin/internal/document"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const maxUploadFileSize = 10 << 20
+
+// UploadInterview extracts the text of an uploaded PDF, DOCX, Markdown or text file and
+// runs AI extraction over it like pasted notes. The file is kept as an attachment.
+func (h *Handler) UploadInterview(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	// the multipart parser spills large files to disk, so the body is capped before parsing,
+	// leaving room for the multipart headers around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadFileSize+1<<20)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.BadRequest(c, "file must be at most 10MB")
+			return
+		}
+		response.BadRequest(c, "file is required")
+		return
+	}
+	if fileHeader.Size > maxUploadFileSize {
+		response.BadRequest(c, "file must be at most 10MB")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.BadRequest(c, "failed to read file")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxUploadFileSize))
+	if err != nil {
+		response.BadRequest(c, "failed to read file")
+		return
+	}
+
+	filename := filepath.Base(fileHeader.Filename)
+	kind, err := document.Detect(filename, data)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	text, err := document.ExtractText(kind, data)
+	if err != nil {
+		h.Logger.Warn("upload_interview: text extraction failed",
+			zap.String("user_id", claims.UserID.String()),
+			zap.String("kind", string(kind)),
+			zap.Error(err),
+		)
+		response.BadRequest(c, fmt.Sprintf("failed to read %s file: %v", kind, err))
+		return
+	}
+
+	unknownCompany, err := h.Repository.GetCompanyByName(c.Request.Context(), claims.UserID, "unknown company")
+	if err != nil {
+		h.Logger.Error("upload_interview: failed to get unknown company",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to get unknown company")
+		return
+	}
+	if unknownCompany == nil {
+		response.InternalError(c, "unknown company not found")
+		return
+	}
+
+	attachment := &model.Attachment{
+		Filename:    filename,
+		ContentType: kind.ContentType(),
+		Size:        len(data),
+		BlobKey:     archive.AttachmentKey(data),
+	}
+
+	response.Accepted(c, gin.H{"message": "Interview processing started, it will be added soon"})
+
+	title := strings.TrimSuffix(filename, filepath.Ext(filename))
+	go func(userID, unknownCompanyID uuid.UUID) {
+		ctx := context.Background()
+		interviewID, _ := h.extractAndSaveInterview(ctx, userID, unknownCompanyID, nil, title, text, model.SourcePersonal, model.Provenance{})
+		if interviewID == nil {
+			return
+		}
+		// the file is only stored once there is an interview to attach it to
+		if err := h.Archive.Put(ctx, attachment.BlobKey, &archive.Blob{ContentType: attachment.ContentType, Data: data}); err != nil {
+			h.Logger.Error("upload_interview: failed to store file",
+				zap.Int64("interview_id", *interviewID),
+				zap.Error(err),
+			)
+			return
+		}
+		attachment.InterviewID = *interviewID
+		if err := h.Repository.CreateAttachment(ctx, userID, attachment); err != nil {
+			h.Logger.Error("upload_interview: failed to save attachment",
+				zap.Int64("interview_id", *interviewID),
+				zap.Error(err),
+			)
+		}
+	}(claims.UserID, unknownCompany.CompanyID)
+}
+
+// ListAttachments returns the files an interview was created from
+func (h *Handler) ListAttachments(c *gin.Context) {
+	interviewID, err := strconv.ParseInt(c.Param("interview_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid interview_id format")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	attachments, err := h.Repository.ListAttachments(c.Request.Context(), claims.UserID, interviewID)
+	if err != nil {
+		h.Logger.Error("list_attachments: failed to fetch",
+			zap.Int64("interview_id", interviewID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch attachments")
+		return
+	}
+
+	response.OK(c, attachments)
+}
+
+// DownloadAttachment returns an attached file
+func (h *Handler) DownloadAttachment(c *gin.Context) {
+	interviewID, err := strconv.ParseInt(c.Param("interview_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid interview_id format")
+		return
+	}
+	attachmentID, err := strconv.ParseInt(c.Param("attachment_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid attachment_id format")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	attachment, err := h.Repository.GetAttachment(c.Request.Context(), claims.UserID, interviewID, attachmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			response.NotFound(c, "attachment not found")
+			return
+		}
+		h.Logger.Error("download_attachment: failed to fetch",
+			zap.Int64("attachment_id", attachmentID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch attachment")
+		return
+	}
+
+	blob, err := h.Archive.Get(c.Request.Context(), attachment.BlobKey)
+	if err != nil {
+		if errors.Is(err, archive.ErrNotFound) {
+			response.NotFound(c, "attachment not found")
+			return
+		}
+		h.Logger.Error("download_attachment: failed to read file",
+			zap.Int64("attachment_id", attachmentID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch attachment")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename=%q`, attachment.Filename))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, attachment.ContentType, blob.Data)
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"strings"
+
 	"github.com/abhishek622/interviewMin/pkg"
 	"github.com/abhishek622/interviewMin/pkg/model"
 	"github.com/abhishek622/interviewMin/pkg/response"
@@ -189,6 +191,61 @@ func (h *Handler) Me(c *gin.Context) {
 	})
 }
 
+// GetPreferences returns the current user's preferences
+func (h *Handler) GetPreferences(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	prefs, err := h.Repository.GetUserPreferences(c.Request.Context(), claims.UserID)
+	if err != nil {
+		h.Logger.Error("get_preferences: failed to fetch",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch preferences")
+		return
+	}
+
+	response.OK(c, prefs)
+}
+
+// UpdatePreferences updates the current user's preferences. search_language selects
+// the stemming used for search queries, "simple" disables stemming.
+func (h *Handler) UpdatePreferences(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	var req model.UpdatePreferencesReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+
+	lang := strings.ToLower(strings.TrimSpace(req.SearchLanguage))
+	if !pkg.SearchLanguages[lang] {
+		response.BadRequest(c, "unsupported search_language")
+		return
+	}
+
+	prefs := &model.UserPreferences{SearchLanguage: lang}
+	if err := h.Repository.UpdateUserPreferences(c.Request.Context(), claims.UserID, prefs); err != nil {
+		h.Logger.Error("update_preferences: failed to update",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to update preferences")
+		return
+	}
+
+	response.OK(c, prefs)
+}
+
 // Logout revokes the current session
 func (h *Handler) Logout(c *gin.Context) {
 	claims := h.GetClaimsFromContext(c)
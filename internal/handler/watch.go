@@ -0,0 +1,516 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhishek622/interviewMin/internal/fetcher"
+	"github.com/abhishek622/interviewMin/internal/notify"
+	"github.com/abhishek622/interviewMin/internal/repository"
+	"github.com/abhishek622/interviewMin/pkg"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/abhishek622/interviewMin/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxWatchListings caps the subreddits and GfG pages of one watch, each is fetched on every poll
+const maxWatchListings = 10
+
+var subredditName = regexp.MustCompile(`^[A-Za-z0-9_]{2,21}$`)
+
+// CreateWatch subscribes the current user to new interview posts about a company. Posts
+// are looked for on LeetCode unless other sources are selected.
+func (h *Handler) CreateWatch(c *gin.Context) {
+	var req model.CreateWatchReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "company is required and digest must be daily, weekly or off")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	watch := &model.CompanyWatch{
+		UserID:      claims.UserID,
+		Company:     strings.ToLower(strings.TrimSpace(req.Company)),
+		LeetcodeTag: pkg.GenerateSlug(req.LeetcodeTag),
+		Sources:     model.WatchSources{Leetcode: true},
+		Digest:      req.Digest,
+	}
+	if watch.LeetcodeTag == "" {
+		watch.LeetcodeTag = pkg.GenerateSlug(watch.Company)
+	}
+	if keyword := strings.ToLower(strings.TrimSpace(req.PositionKeyword)); keyword != "" {
+		watch.PositionKeyword = &keyword
+	}
+	if watch.Digest == "" {
+		watch.Digest = model.DigestDaily
+	}
+	if req.Sources != nil {
+		sources, err := normalizeWatchSources(*req.Sources)
+		if err != nil {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		watch.Sources = sources
+	}
+
+	if err := h.Repository.CreateCompanyWatch(c.Request.Context(), watch); err != nil {
+		if errors.Is(err, repository.ErrWatchExists) {
+			response.Conflict(c, "company is already watched for this position")
+			return
+		}
+		h.Logger.Error("create_watch: failed to create",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to create watch")
+		return
+	}
+
+	response.Created(c, watch)
+}
+
+// normalizeWatchSources cleans up subreddit names and checks that listing pages are GfG pages
+func normalizeWatchSources(in model.WatchSources) (model.WatchSources, error) {
+	out := model.WatchSources{Leetcode: in.Leetcode, Subreddits: []string{}, GfGPages: []string{}}
+	for _, sub := range in.Subreddits {
+		sub = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(sub), "/"), "r/")
+		if !subredditName.MatchString(sub) {
+			return out, fmt.Errorf("invalid subreddit: %s", sub)
+		}
+		out.Subreddits = append(out.Subreddits, sub)
+	}
+	for _, page := range in.GfGPages {
+		page = strings.TrimSpace(page)
+		if source, err := fetcher.DetectSource(page); err != nil || source != model.SourceGFG {
+			return out, fmt.Errorf("gfg_pages must be geeksforgeeks.org urls: %s", page)
+		}
+		out.GfGPages = append(out.GfGPages, page)
+	}
+	if !out.Leetcode && len(out.Subreddits) == 0 && len(out.GfGPages) == 0 {
+		return out, errors.New("at least one source is required")
+	}
+	if len(out.Subreddits)+len(out.GfGPages) > maxWatchListings {
+		return out, fmt.Errorf("at most %d subreddits and gfg pages can be watched", maxWatchListings)
+	}
+	return out, nil
+}
+
+// ListWatches returns the company watches of the current user
+func (h *Handler) ListWatches(c *gin.Context) {
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	watches, err := h.Repository.ListCompanyWatches(c.Request.Context(), claims.UserID)
+	if err != nil {
+		h.Logger.Error("list_watches: failed to fetch",
+			zap.String("user_id", claims.UserID.String()),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to fetch watches")
+		return
+	}
+
+	response.OK(c, watches)
+}
+
+// DeleteWatch removes a company watch of the current user
+func (h *Handler) DeleteWatch(c *gin.Context) {
+	watchID, err := strconv.ParseInt(c.Param("watch_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "invalid watch_id format")
+		return
+	}
+
+	claims := h.GetClaimsFromContext(c)
+	if claims == nil {
+		response.Unauthorized(c, "")
+		return
+	}
+
+	deleted, err := h.Repository.DeleteCompanyWatch(c.Request.Context(), claims.UserID, watchID)
+	if err != nil {
+		h.Logger.Error("delete_watch: failed to delete",
+			zap.Int64("watch_id", watchID),
+			zap.Error(err),
+		)
+		response.InternalError(c, "failed to delete watch")
+		return
+	}
+	if !deleted {
+		response.NotFound(c, "watch not found")
+		return
+	}
+
+	response.Message(c, "watch deleted successfully")
+}
+
+// CrawlLeetcode lists LeetCode discuss posts tagged with a company and imports the ones
+// the user does not have yet. It blocks until the import batch has been processed.
+func (h *Handler) CrawlLeetcode(ctx context.Context, userID uuid.UUID, opts fetcher.DiscussListOptions) (*model.ImportBatch, error) {
+	posts, err := fetcher.ListLeetcodeDiscussPosts(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(posts) == 0 {
+		return nil, nil
+	}
+
+	urls := make([]string, len(posts))
+	for i, p := range posts {
+		urls[i] = p.URL
+	}
+
+	batch, unknownCompanyID, err := h.queueImportBatch(ctx, userID, urls)
+	if err != nil {
+		return nil, err
+	}
+	h.runImportBatch(userID, unknownCompanyID, batch.BatchID, batch.Items)
+
+	return h.Repository.GetImportBatch(ctx, userID, batch.BatchID)
+}
+
+// RunWatchScheduler polls due company watches and sends due digests until ctx is cancelled.
+// Both run in their own goroutine so a slow poll does not hold up digests, and the other way round.
+func (h *Handler) RunWatchScheduler(ctx context.Context) {
+	go runEvery(ctx, h.Config.Watch.DigestInterval, false, h.sendWatchDigests)
+	runEvery(ctx, h.Config.Watch.Interval, true, h.pollWatches)
+}
+
+// runEvery calls run every interval until ctx is cancelled, and once right away when now is set
+func runEvery(ctx context.Context, interval time.Duration, now bool, run func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	if now {
+		run(ctx)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run(ctx)
+		}
+	}
+}
+
+// watchPoller lists all posts one source of a watch published since a time
+type watchPoller struct {
+	source string
+	list   func(ctx context.Context, w model.CompanyWatch, since time.Time) ([]fetcher.ListedPost, error)
+	// byTitle is set for sources that are not tagged with the company, their posts
+	// are only kept when the title names it
+	byTitle bool
+}
+
+// watchPollers returns a poller for every source the watch selects
+func watchPollers(w model.CompanyWatch) []watchPoller {
+	var pollers []watchPoller
+	if w.Sources.Leetcode {
+		pollers = append(pollers, watchPoller{
+			source: "leetcode",
+			list: func(ctx context.Context, w model.CompanyWatch, since time.Time) ([]fetcher.ListedPost, error) {
+				// pages back to since, the poll caps the number of posts it takes
+				posts, err := fetcher.ListLeetcodeDiscussPosts(ctx, fetcher.DiscussListOptions{
+					CompanyTag: w.LeetcodeTag,
+					Since:      since,
+				})
+				return fetcher.ListedLeetcodePosts(posts), err
+			},
+		})
+	}
+	for _, sub := range w.Sources.Subreddits {
+		pollers = append(pollers, watchPoller{
+			source: "r/" + sub,
+			list: func(ctx context.Context, w model.CompanyWatch, since time.Time) ([]fetcher.ListedPost, error) {
+				return fetcher.ListSubredditPosts(ctx, sub, w.Company, since)
+			},
+			byTitle: true,
+		})
+	}
+	for _, page := range w.Sources.GfGPages {
+		pollers = append(pollers, watchPoller{
+			source: page,
+			list: func(ctx context.Context, w model.CompanyWatch, since time.Time) ([]fetcher.ListedPost, error) {
+				return fetcher.ListGfGPosts(ctx, page)
+			},
+			byTitle: true,
+		})
+	}
+	return pollers
+}
+
+// watchMatchesPost tells whether a listed post is about the watched company and position
+func watchMatchesPost(w model.CompanyWatch, p fetcher.ListedPost, byTitle bool) bool {
+	title := strings.ToLower(p.Title)
+	if byTitle && !strings.Contains(title, w.Company) {
+		return false
+	}
+	return w.PositionKeyword == nil || strings.Contains(title, *w.PositionKeyword)
+}
+
+func (h *Handler) pollWatches(ctx context.Context) {
+	now := time.Now()
+
+	watches, err := h.Repository.ListDueWatches(ctx, now.Add(-h.Config.Watch.Interval), 100)
+	if err != nil {
+		h.Logger.Error("watch_scheduler: failed to list watches", zap.Error(err))
+		return
+	}
+
+	for _, w := range watches {
+		if ctx.Err() != nil {
+			return
+		}
+		h.pollWatch(ctx, w, now)
+	}
+}
+
+// pollWatch stores the new posts of every source of a watch and queues their import.
+// Posts are taken oldest first, when a source has more than WATCH_MAX_POSTS new posts
+// the watch is only marked polled up to the last one taken so the next poll continues there.
+func (h *Handler) pollWatch(ctx context.Context, w model.CompanyWatch, now time.Time) {
+	cfg := h.Config.Watch
+	log := h.Logger.With(zap.Int64("watch_id", w.WatchID))
+
+	since := now.Add(-cfg.Lookback)
+	if w.LastPolledAt != nil {
+		since = *w.LastPolledAt
+	}
+
+	polledUntil := now
+	var found []model.WatchMatch
+	for _, p := range watchPollers(w) {
+		posts, err := p.list(ctx, w, since)
+		if err != nil {
+			log.Warn("watch_scheduler: listing failed", zap.String("source", p.source), zap.Error(err))
+			// the source is listed from the same point again next time
+			polledUntil = since
+			continue
+		}
+		sortPostsOldestFirst(posts)
+
+		kept := 0
+		var last *time.Time
+		for _, post := range posts {
+			if kept == cfg.MaxPosts {
+				// posts after the last one taken are left for the next poll
+				if last == nil {
+					last = &since
+				}
+				if last.Before(polledUntil) {
+					polledUntil = *last
+				}
+				break
+			}
+			if post.CreatedAt != nil {
+				last = post.CreatedAt
+			}
+			if !watchMatchesPost(w, post, p.byTitle) {
+				continue
+			}
+			canonicalURL, err := fetcher.CanonicalURL(post.URL, post.Source)
+			if err != nil {
+				continue
+			}
+			found = append(found, model.WatchMatch{
+				Source:       post.Source,
+				URL:          post.URL,
+				CanonicalURL: canonicalURL,
+				Title:        post.Title,
+				PostedAt:     post.CreatedAt,
+			})
+			kept++
+		}
+	}
+
+	if err := h.Repository.MarkWatchPolled(ctx, w.WatchID, polledUntil); err != nil {
+		log.Warn("watch_scheduler: failed to mark watch polled", zap.Error(err))
+	}
+	if len(found) == 0 {
+		return
+	}
+
+	matches, err := h.Repository.CreateWatchMatches(ctx, w, found)
+	if err != nil {
+		log.Error("watch_scheduler: failed to store matches", zap.Error(err))
+		return
+	}
+	if len(matches) == 0 {
+		return
+	}
+
+	batchID, err := h.queueWatchImports(ctx, w, matches)
+	if err != nil {
+		log.Error("watch_scheduler: failed to queue import", zap.Error(err))
+		return
+	}
+
+	log.Info("watch_scheduler: watch polled",
+		zap.Int("new_posts", len(matches)),
+		zap.Int64("batch_id", batchID),
+	)
+}
+
+// queueWatchImports stores new matches as queued interviews of the watched company and
+// leaves their import to the batch worker, so polling does not wait for the extraction
+func (h *Handler) queueWatchImports(ctx context.Context, w model.CompanyWatch, matches []model.WatchMatch) (int64, error) {
+	unknownCompany, err := h.Repository.GetCompanyByName(ctx, w.UserID, "unknown company")
+	if err != nil {
+		return 0, fmt.Errorf("get unknown company: %w", err)
+	}
+	if unknownCompany == nil {
+		return 0, errors.New("unknown company not found")
+	}
+	companyID, err := h.resolveCompany(ctx, w.UserID, w.Company)
+	if err != nil {
+		return 0, fmt.Errorf("resolve watched company: %w", err)
+	}
+
+	items := make([]model.ImportBatchItem, len(matches))
+	matchIDs := make([]int64, len(matches))
+	for i, m := range matches {
+		matchIDs[i] = m.MatchID
+		it := &items[i]
+		it.URL = m.URL
+		it.CanonicalURL = &m.CanonicalURL
+		it.Source = &m.Source
+		it.Status = model.BatchItemQueued
+
+		interviewID, err := h.Repository.CreateInterview(ctx, &model.Interview{
+			UserID:        w.UserID,
+			Source:        m.Source,
+			RawInput:      m.URL,
+			ProcessStatus: model.ProcessStatusQueued,
+			Metadata:      map[string]interface{}{"title": m.Title},
+			CompanyID:     companyID,
+			Provenance: model.Provenance{
+				SourceURL:         &m.URL,
+				CanonicalURL:      &m.CanonicalURL,
+				SourcePublishedAt: m.PostedAt,
+			},
+		})
+		if errors.Is(err, repository.ErrInterviewExists) {
+			existingID, _ := h.Repository.InterviewIDByCanonicalURL(ctx, w.UserID, m.CanonicalURL)
+			it.Status = model.BatchItemDuplicate
+			it.InterviewID = &existingID
+			it.Error = pkg.StringPtr("already imported")
+			continue
+		}
+		if err != nil {
+			h.Logger.Error("watch_scheduler: failed to create queued interview", zap.String("url", m.URL), zap.Error(err))
+			it.Status = model.BatchItemFailed
+			it.Error = pkg.StringPtr("failed to queue interview")
+			continue
+		}
+		it.InterviewID = interviewID
+	}
+
+	batchID, err := h.Repository.CreateImportBatch(ctx, w.UserID, items)
+	if err != nil {
+		return 0, err
+	}
+	if err := h.Repository.SetWatchMatchesBatch(ctx, matchIDs, batchID); err != nil {
+		h.Logger.Warn("watch_scheduler: failed to link matches to batch", zap.Int64("batch_id", batchID), zap.Error(err))
+	}
+
+	go h.runImportBatch(w.UserID, unknownCompany.CompanyID, batchID, items)
+	return batchID, nil
+}
+
+// sortPostsOldestFirst orders listed posts by creation time, posts without one go last
+func sortPostsOldestFirst(posts []fetcher.ListedPost) {
+	sort.SliceStable(posts, func(i, j int) bool {
+		a, b := posts[i].CreatedAt, posts[j].CreatedAt
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.Before(*b)
+	})
+}
+
+// sendWatchDigests sends every user the posts found since their last daily or weekly digest
+func (h *Handler) sendWatchDigests(ctx context.Context) {
+	now := time.Now()
+
+	matches, err := h.Repository.ListDigestMatches(ctx, now.Add(-24*time.Hour), now.Add(-7*24*time.Hour))
+	if err != nil {
+		h.Logger.Error("watch_digest: failed to list matches", zap.Error(err))
+		return
+	}
+
+	type digestKey struct {
+		userID uuid.UUID
+		period model.DigestPeriod
+	}
+	groups := map[digestKey][]model.WatchMatch{}
+	var keys []digestKey
+	for _, m := range matches {
+		key := digestKey{m.UserID, m.Digest}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], m)
+	}
+
+	for _, key := range keys {
+		if ctx.Err() != nil {
+			return
+		}
+		log := h.Logger.With(zap.String("user_id", key.userID.String()), zap.String("period", string(key.period)))
+
+		user, err := h.Repository.GetUserByID(ctx, key.userID)
+		if err != nil {
+			log.Error("watch_digest: failed to fetch user", zap.Error(err))
+			continue
+		}
+
+		digest := notify.Digest{
+			UserID: key.userID.String(),
+			Email:  user.Email,
+			Name:   user.Name,
+			Period: string(key.period),
+		}
+		seenWatch := map[int64]bool{}
+		var watchIDs, matchIDs []int64
+		for _, m := range groups[key] {
+			digest.Items = append(digest.Items, notify.DigestItem{
+				Company:     m.Company,
+				Title:       m.Title,
+				URL:         m.URL,
+				Source:      string(m.Source),
+				PostedAt:    m.PostedAt,
+				InterviewID: m.InterviewID,
+			})
+			matchIDs = append(matchIDs, m.MatchID)
+			if !seenWatch[m.WatchID] {
+				seenWatch[m.WatchID] = true
+				watchIDs = append(watchIDs, m.WatchID)
+			}
+		}
+
+		if err := h.Notifier.Send(ctx, digest); err != nil {
+			log.Error("watch_digest: failed to send", zap.String("notifier", h.Notifier.Name()), zap.Error(err))
+			continue
+		}
+		if err := h.Repository.MarkDigestSent(ctx, watchIDs, matchIDs, now); err != nil {
+			log.Error("watch_digest: failed to mark digest sent", zap.Error(err))
+		}
+	}
+}
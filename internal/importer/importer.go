@@ -0,0 +1,259 @@
+// Package importer reads interviews from CSV files and from the project's own JSON export
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhishek622/interviewMin/internal/export"
+	"github.com/abhishek622/interviewMin/pkg/model"
+)
+
+// maxQuestionsPerRow guards against a whole sheet pasted into one cell
+const maxQuestionsPerRow = 100
+
+// Row is one interview read from an import file
+type Row struct {
+	// Line is the CSV line, or the 1-based position in a JSON export
+	Line      int
+	Interview model.ExportInterview
+	Errors    []model.ImportRowError
+}
+
+func (r *Row) fail(field, format string, args ...interface{}) {
+	r.Errors = append(r.Errors, model.ImportRowError{Row: r.Line, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// defaultColumns are the headers recognised for each field when no mapping is given.
+// They include the columns of the CSV export.
+var defaultColumns = map[string][]string{
+	"company":       {"company", "company_name"},
+	"position":      {"position", "role", "job_title"},
+	"source":        {"source"},
+	"title":         {"title"},
+	"no_of_round":   {"no_of_round", "rounds", "number_of_rounds"},
+	"location":      {"location", "city"},
+	"experience":    {"experience", "full_experience", "raw_input", "raw_text", "text"},
+	"questions":     {"questions", "question"},
+	"question_type": {"question_type", "type"},
+	"tags":          {"tags", "labels"},
+	"created_at":    {"created_at", "date"},
+}
+
+// ParseCSV reads one interview per CSV record. mapping maps an import field to a column
+// header and overrides the default headers. Multiple questions or tags in a cell are
+// separated by newlines or semicolons.
+func ParseCSV(r io.Reader, mapping map[string]string) ([]Row, error) {
+	for field := range mapping {
+		if _, ok := defaultColumns[field]; !ok {
+			return nil, fmt.Errorf("unknown mapping field: %s", field)
+		}
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+
+	positions := map[string]int{}
+	for i, h := range header {
+		positions[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+
+	columns := map[string]int{}
+	for field, aliases := range defaultColumns {
+		if col, ok := mapping[field]; ok {
+			idx, found := positions[strings.ToLower(strings.TrimSpace(col))]
+			if !found {
+				return nil, fmt.Errorf("mapped column %q for %s not found in header", col, field)
+			}
+			columns[field] = idx
+			continue
+		}
+		for _, alias := range aliases {
+			if idx, found := positions[alias]; found {
+				columns[field] = idx
+				break
+			}
+		}
+	}
+	if _, ok := columns["company"]; !ok {
+		return nil, errors.New("no company column, map one with the mapping parameter")
+	}
+
+	var rows []Row
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		row := Row{Line: line}
+		if err != nil {
+			row.fail("", "invalid csv record: %v", err)
+			rows = append(rows, row)
+			continue
+		}
+
+		get := func(field string) string {
+			idx, ok := columns[field]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		iv := model.ExportInterview{
+			Company:        get("company"),
+			Source:         model.Source(strings.ToLower(get("source"))),
+			Title:          get("title"),
+			FullExperience: get("experience"),
+			Tags:           splitList(get("tags")),
+		}
+		iv.RawInput = iv.FullExperience
+		if v := get("position"); v != "" {
+			iv.Position = &v
+		}
+		if v := get("location"); v != "" {
+			iv.Location = &v
+		}
+		if v := get("created_at"); v != "" {
+			t, err := parseDate(v)
+			if err != nil {
+				row.fail("created_at", "created_at must be a date like 2024-05-31, got %q", v)
+			} else {
+				iv.CreatedAt = t
+			}
+		}
+		if v := get("no_of_round"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				row.fail("no_of_round", "no_of_round must be a number, got %q", v)
+			} else {
+				iv.NoOfRound = &n
+			}
+		}
+
+		qType := get("question_type")
+		for _, q := range splitList(get("questions")) {
+			iv.Questions = append(iv.Questions, model.ExportQuestion{Question: q, Type: qType})
+		}
+
+		row.Interview = iv
+		Validate(&row)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ParseJSON reads a JSON export, either the bare JSON file or the zip archive it was
+// downloaded in. The export's companies are returned too, they include companies
+// without interviews.
+func ParseJSON(data []byte) ([]Row, []model.ExportCompany, error) {
+	if bytes.HasPrefix(data, []byte("PK")) {
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, nil, fmt.Errorf("open export archive: %w", err)
+		}
+		f, err := zr.Open(export.JSONFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("archive has no %s, only json exports can be imported", export.JSONFile)
+		}
+		defer f.Close()
+		if data, err = io.ReadAll(f); err != nil {
+			return nil, nil, fmt.Errorf("read %s: %w", export.JSONFile, err)
+		}
+	}
+
+	var archive model.ExportArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return nil, nil, fmt.Errorf("decode export: %w", err)
+	}
+	if archive.Version < 1 || archive.Version > model.ExportVersion {
+		return nil, nil, fmt.Errorf("unsupported export version %d", archive.Version)
+	}
+
+	rows := make([]Row, len(archive.Interviews))
+	for i, iv := range archive.Interviews {
+		rows[i] = Row{Line: i + 1, Interview: iv}
+		Validate(&rows[i])
+	}
+	return rows, archive.Companies, nil
+}
+
+// Validate normalizes a row and records what is wrong with it
+func Validate(row *Row) {
+	iv := &row.Interview
+
+	iv.Company = strings.TrimSpace(iv.Company)
+	if iv.Company == "" {
+		row.fail("company", "company is required")
+	}
+
+	switch iv.Source {
+	case "":
+		iv.Source = model.SourcePersonal
+	case model.SourceLeetcode, model.SourceReddit, model.SourceGFG, model.SourceOther, model.SourcePersonal:
+	default:
+		row.fail("source", "unknown source %q", iv.Source)
+	}
+
+	if iv.NoOfRound != nil && (*iv.NoOfRound < 1 || *iv.NoOfRound > 100) {
+		row.fail("no_of_round", "no_of_round must be between 1 and 100")
+	}
+
+	if strings.TrimSpace(iv.FullExperience) == "" && strings.TrimSpace(iv.RawInput) == "" && len(iv.Questions) == 0 {
+		row.fail("", "row has neither an experience nor questions")
+	}
+
+	if len(iv.Questions) > maxQuestionsPerRow {
+		row.fail("questions", "at most %d questions per interview", maxQuestionsPerRow)
+	}
+	for i := range iv.Questions {
+		q := &iv.Questions[i]
+		q.Question = strings.TrimSpace(q.Question)
+		switch strings.ToLower(strings.ReplaceAll(strings.TrimSpace(q.Type), " ", "_")) {
+		case "dsa", "coding":
+			q.Type = "dsa"
+		case "system_design", "design":
+			q.Type = "system_design"
+		case "behavioral", "behavioural", "hr":
+			q.Type = "behavioral"
+		default:
+			q.Type = "other"
+		}
+	}
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == '\n' || r == ';' }) {
+		if p := strings.TrimSpace(part); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// parseDate accepts the export's RFC 3339 timestamps and plain dates
+func parseDate(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", v)
+}
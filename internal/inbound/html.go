@@ -0,0 +1,97 @@
+package inbound
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	// quoted replies and signatures as marked up by common mail clients
+	quotedMarkup = regexp.MustCompile(`(?i)\b(gmail_quote|gmail_signature|gmail_extra|moz-cite-prefix|moz-signature|yahoo_quoted|protonmail_quote|x_divRplyFwdMsg)\b`)
+	spaces       = regexp.MustCompile(`[ \t\r\n\f]+`)
+)
+
+// blockElements start and end on their own line
+var blockElements = map[string]bool{
+	"address": true, "article": true, "blockquote": true, "dd": true, "div": true, "dl": true, "dt": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "hr": true, "li": true,
+	"ol": true, "p": true, "pre": true, "section": true, "table": true, "tr": true, "ul": true,
+}
+
+// htmlToText converts an HTML body to plain text, blockquotes and the elements mail
+// clients wrap quoted replies and signatures in are left out
+func htmlToText(body string) string {
+	if strings.TrimSpace(body) == "" {
+		return ""
+	}
+	doc, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	var b strings.Builder
+	newline := func() {
+		if s := b.String(); s != "" && !strings.HasSuffix(s, "\n") {
+			b.WriteString("\n")
+		}
+	}
+
+	var walk func(n *html.Node, pre bool)
+	walk = func(n *html.Node, pre bool) {
+		switch n.Type {
+		case html.TextNode:
+			text := n.Data
+			if !pre {
+				text = spaces.ReplaceAllString(text, " ")
+				if strings.HasSuffix(b.String(), "\n") || b.Len() == 0 {
+					text = strings.TrimLeft(text, " ")
+				}
+			}
+			b.WriteString(text)
+			return
+		case html.ElementNode:
+			switch n.Data {
+			case "head", "script", "style", "title", "blockquote":
+				return
+			case "br":
+				b.WriteString("\n")
+				return
+			}
+			if quotedMarkup.MatchString(attr(n, "class")) || quotedMarkup.MatchString(attr(n, "id")) ||
+				attr(n, "id") == "divRplyFwdMsg" || attr(n, "id") == "appendonsend" {
+				return
+			}
+			pre = pre || n.Data == "pre"
+		}
+
+		block := n.Type == html.ElementNode && blockElements[n.Data]
+		if block {
+			newline()
+			if n.Data == "li" {
+				b.WriteString("- ")
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, pre)
+		}
+		if block {
+			newline()
+			if n.Data == "p" || strings.HasPrefix(n.Data, "h") && len(n.Data) == 2 {
+				b.WriteString("\n")
+			}
+		}
+	}
+	walk(doc, false)
+	return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
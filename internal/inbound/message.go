@@ -0,0 +1,188 @@
+// Package inbound parses emails sent to the ingest addresses into the text of an interview
+package inbound
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// ErrNoText is returned for messages without a text or HTML body
+var ErrNoText = errors.New("message has no text body")
+
+// maxDepth bounds the nesting of multipart and forwarded message parts
+const maxDepth = 8
+
+// Message is the part of an inbound email used for ingestion
+type Message struct {
+	MessageID  string
+	From       string
+	Recipients []string // envelope and header recipients, lowercased
+	Subject    string
+	Text       string // body with quoted replies and signatures removed
+}
+
+var wordDecoder = &mime.WordDecoder{CharsetReader: charset.NewReaderLabel}
+
+// Parse reads a raw RFC 5322 message. The plain text body is preferred, an HTML-only
+// body is converted to text.
+func Parse(r io.Reader) (*Message, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("read message: %w", err)
+	}
+
+	m := &Message{
+		MessageID: strings.Trim(strings.TrimSpace(msg.Header.Get("Message-Id")), "<>"),
+		Subject:   decodeHeader(msg.Header.Get("Subject")),
+	}
+	if from, err := mail.ParseAddress(decodeHeader(msg.Header.Get("From"))); err == nil {
+		m.From = strings.ToLower(from.Address)
+	}
+	m.Recipients = recipients(msg.Header)
+
+	var body bodies
+	if err := body.walk(textproto.MIMEHeader(msg.Header), msg.Body, 0); err != nil {
+		return nil, err
+	}
+	text := body.plain
+	if strings.TrimSpace(text) == "" {
+		text = htmlToText(body.html)
+	}
+	m.Text = StripReply(text)
+	if m.Text == "" {
+		return nil, ErrNoText
+	}
+	return m, nil
+}
+
+func decodeHeader(v string) string {
+	if decoded, err := wordDecoder.DecodeHeader(v); err == nil {
+		v = decoded
+	}
+	return strings.TrimSpace(v)
+}
+
+// recipients lists the envelope headers first, mail servers add them for the address
+// the message was actually delivered to, which may be a Bcc
+func recipients(h mail.Header) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, key := range []string{"Delivered-To", "X-Original-To", "Envelope-To", "To", "Cc"} {
+		for _, v := range h[textproto.CanonicalMIMEHeaderKey(key)] {
+			list, err := mail.ParseAddressList(decodeHeader(v))
+			if err != nil {
+				continue
+			}
+			for _, a := range list {
+				addr := strings.ToLower(a.Address)
+				if !seen[addr] {
+					seen[addr] = true
+					out = append(out, addr)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// bodies collects the first plain text and the first HTML body of a message
+type bodies struct {
+	plain string
+	html  string
+}
+
+func (b *bodies) walk(header textproto.MIMEHeader, r io.Reader, depth int) error {
+	if depth > maxDepth {
+		return nil
+	}
+	if disp, _, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil && disp == "attachment" {
+		return nil
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		// RFC 2045 default for a missing or broken content type
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		mr := multipart.NewReader(r, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("read multipart: %w", err)
+			}
+			if err := b.walk(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+	case mediaType == "message/rfc822":
+		// a message forwarded as an attachment is used when the outer one has no text
+		inner, err := mail.ReadMessage(decodeTransfer(header, r))
+		if err != nil {
+			return nil
+		}
+		return b.walk(textproto.MIMEHeader(inner.Header), inner.Body, depth+1)
+	case mediaType == "text/plain" && b.plain == "":
+		text, err := readText(header, params, r)
+		if err != nil {
+			return err
+		}
+		b.plain = text
+	case mediaType == "text/html" && b.html == "":
+		text, err := readText(header, params, r)
+		if err != nil {
+			return err
+		}
+		b.html = text
+	}
+	return nil
+}
+
+func decodeTransfer(header textproto.MIMEHeader, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(header.Get("Content-Transfer-Encoding"))) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+// readText decodes a text part to UTF-8
+func readText(header textproto.MIMEHeader, params map[string]string, r io.Reader) (string, error) {
+	data, err := io.ReadAll(decodeTransfer(header, r))
+	if err != nil {
+		return "", fmt.Errorf("decode body: %w", err)
+	}
+	cs := strings.ToLower(params["charset"])
+	if cs == "" || cs == "utf-8" || cs == "us-ascii" {
+		return string(data), nil
+	}
+	cr, err := charset.NewReaderLabel(cs, bytes.NewReader(data))
+	if err != nil {
+		// unknown charsets are read as is rather than dropping the message
+		return string(data), nil
+	}
+	decoded, err := io.ReadAll(cr)
+	if err != nil {
+		return "", fmt.Errorf("decode charset %s: %w", cs, err)
+	}
+	return string(decoded), nil
+}
@@ -0,0 +1,95 @@
+package inbound
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// "On Mon, 3 Jun 2024 at 10:02, Jane <jane@example.com> wrote:", sometimes wrapped over two lines
+	replyHeader    = regexp.MustCompile(`(?i)^(on|am|le|el) .{4,300}(wrote|schrieb|a écrit|escribió)\s?:$`)
+	originalHeader = regexp.MustCompile(`(?i)^-{2,}\s*(original message|forwarded message|weitergeleitete nachricht)\s*-{2,}$`)
+	separatorLine  = regexp.MustCompile(`^_{10,}$`)
+	headerLine     = regexp.MustCompile(`(?i)^\*?(from|sent|date|to|cc|subject)\s?:\*?\s`)
+	mobileFooter   = regexp.MustCompile(`(?i)^(sent from my .{1,40}|get outlook for .{1,20}|sent from (mail|outlook) for .{1,20})$`)
+	blankLines     = regexp.MustCompile(`\n{3,}`)
+)
+
+// StripReply removes quoted replies and the signature from a plain text body. A
+// forwarded message header at the very top is removed and the forwarded text kept,
+// since forwarding a note to the ingest address is a common way to send one.
+func StripReply(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\u00a0", " ")
+	lines := strings.Split(text, "\n")
+
+	var kept []string
+	hasContent := func() bool {
+		for _, l := range kept {
+			if strings.TrimSpace(l) != "" {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		// "-- " is the standard signature delimiter
+		if line == "--" || line == "-- " || mobileFooter.MatchString(trimmed) {
+			break
+		}
+
+		header := originalHeader.MatchString(trimmed) ||
+			separatorLine.MatchString(trimmed) && i+1 < len(lines) && headerLine.MatchString(strings.TrimSpace(lines[i+1])) ||
+			headerBlock(lines[i:])
+		if header {
+			if hasContent() {
+				break
+			}
+			// skip the forwarded message's header lines and keep its body
+			i++
+			for i < len(lines) && (headerLine.MatchString(strings.TrimSpace(lines[i])) || strings.TrimSpace(lines[i]) == "") {
+				i++
+			}
+			i--
+			continue
+		}
+
+		wrapped := !replyHeader.MatchString(trimmed) &&
+			i+1 < len(lines) && replyHeader.MatchString(trimmed+" "+strings.TrimSpace(lines[i+1]))
+		if wrapped || replyHeader.MatchString(trimmed) {
+			if hasContent() {
+				break
+			}
+			if wrapped {
+				i++
+			}
+			continue
+		}
+
+		kept = append(kept, line)
+	}
+
+	out := blankLines.ReplaceAllString(strings.Join(kept, "\n"), "\n\n")
+	return strings.TrimSpace(out)
+}
+
+// headerBlock tells whether lines start with an Outlook style "From:" and "Sent:" block
+func headerBlock(lines []string) bool {
+	if len(lines) < 2 || !strings.HasPrefix(strings.ToLower(strings.TrimLeft(strings.TrimSpace(lines[0]), "*")), "from:") {
+		return false
+	}
+	for _, l := range lines[1:min(len(lines), 4)] {
+		l = strings.ToLower(strings.TrimLeft(strings.TrimSpace(l), "*"))
+		if strings.HasPrefix(l, "sent:") || strings.HasPrefix(l, "date:") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package leetcode
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/abhishek622/interviewMin/internal/fetcher"
+	"github.com/abhishek622/interviewMin/pkg"
+)
+
+// embeddedProblems is a small seed of popular problems so matching works offline. The
+// full list comes from Refresh, which the API runs on startup and then periodically.
+//
+//go:embed problems.json
+var embeddedProblems []byte
+
+// refreshPageSize is the number of problems requested per GraphQL page during a refresh
+const refreshPageSize = 100
+
+var problemNumberRe = regexp.MustCompile(`(?i)\b(?:lc|leetcode)\s*(?:#|no\.?|problem)?\s*(\d{1,4})\b`)
+
+// Problem is a LeetCode problem in the catalog
+type Problem = fetcher.LeetcodeProblem
+
+// Candidate is a problem scored against a question
+type Candidate struct {
+	Problem Problem
+	Score   float64
+}
+
+// Catalog holds the LeetCode problem list used for matching. It starts from the
+// embedded snapshot and can be refreshed from LeetCode at runtime.
+type Catalog struct {
+	mu         sync.RWMutex
+	problems   []Problem
+	normalized []string
+	bySlug     map[string]int
+	byID       map[int]int
+	file       string
+	seed       bool // still the embedded list
+}
+
+// NewCatalog creates a catalog from the embedded problem list. When file is set and
+// exists it is loaded instead, and refreshed lists are written back to it.
+func NewCatalog(file string) (*Catalog, error) {
+	c := &Catalog{file: file}
+
+	data := embeddedProblems
+	c.seed = true
+	if file != "" {
+		if b, err := os.ReadFile(file); err == nil {
+			data = b
+			c.seed = false
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("read problems file: %w", err)
+		}
+	}
+
+	var problems []Problem
+	if err := json.Unmarshal(data, &problems); err != nil {
+		return nil, fmt.Errorf("decode problems: %w", err)
+	}
+	c.set(problems)
+
+	return c, nil
+}
+
+func (c *Catalog) set(problems []Problem) {
+	normalized := make([]string, len(problems))
+	bySlug := make(map[string]int, len(problems))
+	byID := make(map[int]int, len(problems))
+	for i, p := range problems {
+		normalized[i] = pkg.NormalizeText(p.Title)
+		bySlug[p.Slug] = i
+		byID[p.ID] = i
+	}
+
+	c.mu.Lock()
+	c.problems = problems
+	c.normalized = normalized
+	c.bySlug = bySlug
+	c.byID = byID
+	c.mu.Unlock()
+}
+
+// Len returns the number of problems in the catalog
+func (c *Catalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.problems)
+}
+
+// Seeded reports whether the catalog still holds only the embedded seed list
+func (c *Catalog) Seeded() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.seed
+}
+
+// BySlug looks up a problem by its title slug
+func (c *Catalog) BySlug(slug string) (Problem, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	i, ok := c.bySlug[slug]
+	if !ok {
+		return Problem{}, false
+	}
+	return c.problems[i], true
+}
+
+// Refresh downloads the full problem list from LeetCode and replaces the catalog
+func (c *Catalog) Refresh(ctx context.Context) (int, error) {
+	var all []Problem
+	for skip := 0; ; skip += refreshPageSize {
+		page, total, err := fetcher.GetLeetcodeProblems(ctx, skip, refreshPageSize)
+		if err != nil {
+			return 0, err
+		}
+		all = append(all, page...)
+		if len(page) == 0 || skip+refreshPageSize >= total {
+			break
+		}
+	}
+
+	if len(all) == 0 {
+		return 0, fmt.Errorf("leetcode returned an empty problem list")
+	}
+
+	if c.file != "" {
+		data, err := json.Marshal(all)
+		if err != nil {
+			return 0, fmt.Errorf("encode problems: %w", err)
+		}
+		if err := os.WriteFile(c.file, data, 0o644); err != nil {
+			return 0, fmt.Errorf("write problems file: %w", err)
+		}
+	}
+
+	c.set(all)
+	c.mu.Lock()
+	c.seed = false
+	c.mu.Unlock()
+	return len(all), nil
+}
+
+// Match scores the catalog against a question and returns up to limit candidates
+// at or above minScore, best first
+func (c *Catalog) Match(question string, minScore float64, limit int) []Candidate {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	// an explicit problem number ("LC 146") wins outright
+	if m := problemNumberRe.FindStringSubmatch(question); len(m) == 2 {
+		if id, err := strconv.Atoi(m[1]); err == nil {
+			if i, ok := c.byID[id]; ok {
+				return []Candidate{{Problem: c.problems[i], Score: 1}}
+			}
+		}
+	}
+
+	q := pkg.NormalizeText(question)
+	padded := " " + q + " "
+
+	var out []Candidate
+	for i, title := range c.normalized {
+		score := pkg.Similarity(q, title)
+		// the title quoted inside a longer question
+		if strings.Count(title, " ") > 0 && strings.Contains(padded, " "+title+" ") {
+			score = max(score, 0.95)
+		}
+		if score >= minScore {
+			out = append(out, Candidate{Problem: c.problems[i], Score: score})
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
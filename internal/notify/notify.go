@@ -0,0 +1,209 @@
+// Package notify delivers watch digests to users
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DigestItem is one new interview post in a digest
+type DigestItem struct {
+	Company     string     `json:"company"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url"`
+	Source      string     `json:"source"`
+	PostedAt    *time.Time `json:"posted_at"`
+	InterviewID *int64     `json:"interview_id"`
+}
+
+// Digest collects the new posts of a user's watches since the last digest
+type Digest struct {
+	UserID string       `json:"user_id"`
+	Email  string       `json:"email"`
+	Name   string       `json:"name"`
+	Period string       `json:"period"` // daily | weekly
+	Items  []DigestItem `json:"items"`
+}
+
+// Notifier sends digests
+type Notifier interface {
+	Send(ctx context.Context, d Digest) error
+	Name() string
+}
+
+type Options struct {
+	Provider   string // log | webhook | smtp
+	WebhookURL string
+	SMTPAddr   string // host:port
+	SMTPUser   string
+	SMTPPass   string
+	From       string
+	Timeout    time.Duration
+}
+
+// New returns the notifier for opts.Provider, digests are only logged by default
+func New(opts Options, log *zap.Logger) (Notifier, error) {
+	switch opts.Provider {
+	case "", "log":
+		return &logNotifier{log: log}, nil
+	case "webhook":
+		if opts.WebhookURL == "" {
+			return nil, fmt.Errorf("webhook notifier requires a url")
+		}
+		return &webhookNotifier{url: opts.WebhookURL, client: &http.Client{Timeout: opts.Timeout}}, nil
+	case "smtp":
+		if opts.SMTPAddr == "" || opts.From == "" {
+			return nil, fmt.Errorf("smtp notifier requires an address and a from address")
+		}
+		return &smtpNotifier{opts: opts}, nil
+	}
+	return nil, fmt.Errorf("unknown notifier: %s", opts.Provider)
+}
+
+type logNotifier struct {
+	log *zap.Logger
+}
+
+func (n *logNotifier) Name() string { return "log" }
+
+func (n *logNotifier) Send(ctx context.Context, d Digest) error {
+	n.log.Info("notify: digest",
+		zap.String("user_id", d.UserID),
+		zap.String("period", d.Period),
+		zap.Int("items", len(d.Items)),
+	)
+	return nil
+}
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (n *webhookNotifier) Name() string { return "webhook" }
+
+func (n *webhookNotifier) Send(ctx context.Context, d Digest) error {
+	body, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("marshal digest: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send digest webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("digest webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+type smtpNotifier struct {
+	opts Options
+}
+
+func (n *smtpNotifier) Name() string { return "smtp" }
+
+func (n *smtpNotifier) Send(ctx context.Context, d Digest) error {
+	if d.Email == "" {
+		return fmt.Errorf("user has no email address")
+	}
+
+	host := n.opts.SMTPAddr
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		host = host[:i]
+	}
+	var auth smtp.Auth
+	if n.opts.SMTPUser != "" {
+		auth = smtp.PlainAuth("", n.opts.SMTPUser, n.opts.SMTPPass, host)
+	}
+
+	subject := fmt.Sprintf("Your %s interview digest: %d new posts", d.Period, len(d.Items))
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n", n.opts.From, d.Email, subject)
+	fmt.Fprintf(&msg, "Hi %s,\r\n\r\nNew interview experiences for the companies you watch:\r\n\r\n", d.Name)
+	company := ""
+	for _, item := range d.Items {
+		if item.Company != company {
+			company = item.Company
+			fmt.Fprintf(&msg, "%s\r\n", strings.ToUpper(company))
+		}
+		fmt.Fprintf(&msg, "  - %s (%s)\r\n    %s\r\n", item.Title, item.Source, item.URL)
+	}
+
+	return n.sendMail(ctx, host, auth, d.Email, []byte(msg.String()))
+}
+
+// sendMail does what smtp.SendMail does, on a connection that is closed when ctx is
+// cancelled and that gives up after the notifier timeout
+func (n *smtpNotifier) sendMail(ctx context.Context, host string, auth smtp.Auth, to string, msg []byte) error {
+	if n.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.opts.Timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", n.opts.SMTPAddr)
+	if err != nil {
+		return fmt.Errorf("dial smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if auth != nil {
+		if ok, _ := c.Extension("AUTH"); ok {
+			if err := c.Auth(auth); err != nil {
+				return fmt.Errorf("smtp auth: %w", err)
+			}
+		}
+	}
+	if err := c.Mail(n.opts.From); err != nil {
+		return fmt.Errorf("smtp mail from: %w", err)
+	}
+	if err := c.Rcpt(to); err != nil {
+		return fmt.Errorf("smtp rcpt to: %w", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("write smtp message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	return c.Quit()
+}
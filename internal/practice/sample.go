@@ -0,0 +1,33 @@
+package practice
+
+import "math/rand/v2"
+
+// WeightedSample picks up to n distinct indexes from weights without replacement,
+// where an index is picked with probability proportional to its weight
+func WeightedSample(weights []int, n int, rng *rand.Rand) []int {
+	remaining := make([]int, len(weights))
+	copy(remaining, weights)
+
+	total := 0
+	for _, w := range remaining {
+		total += max(w, 0)
+	}
+
+	out := make([]int, 0, n)
+	for len(out) < n && total > 0 {
+		pick := rng.IntN(total)
+		for i, w := range remaining {
+			if w <= 0 {
+				continue
+			}
+			if pick < w {
+				out = append(out, i)
+				total -= w
+				remaining[i] = 0
+				break
+			}
+			pick -= w
+		}
+	}
+	return out
+}
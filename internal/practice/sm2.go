@@ -0,0 +1,59 @@
+package practice
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// DefaultEaseFactor is the starting ease for a question that has never been reviewed
+	DefaultEaseFactor = 2.5
+	minEaseFactor     = 1.3
+	// passingQuality is the lowest grade that counts as a successful recall
+	passingQuality = 3
+)
+
+// State is the SM-2 scheduling state of a single question
+type State struct {
+	EaseFactor   float64
+	IntervalDays int
+	Repetitions  int
+}
+
+// Schedule applies an SM-2 review with the given quality (0..5) and returns the
+// new state together with the time the question is due again
+func Schedule(s State, quality int, now time.Time) (State, time.Time) {
+	quality = max(0, min(5, quality))
+	if s.EaseFactor == 0 {
+		s.EaseFactor = DefaultEaseFactor
+	}
+
+	if quality >= passingQuality {
+		switch s.Repetitions {
+		case 0:
+			s.IntervalDays = 1
+		case 1:
+			s.IntervalDays = 6
+		default:
+			s.IntervalDays = int(math.Round(float64(s.IntervalDays) * s.EaseFactor))
+		}
+		s.Repetitions++
+	} else {
+		// failed recall starts the repetition cycle over
+		s.Repetitions = 0
+		s.IntervalDays = 1
+	}
+
+	q := float64(5 - quality)
+	s.EaseFactor += 0.1 - q*(0.08+q*0.02)
+	if s.EaseFactor < minEaseFactor {
+		s.EaseFactor = minEaseFactor
+	}
+
+	return s, now.AddDate(0, 0, s.IntervalDays)
+}
+
+// Passed reports whether a review quality counts as a successful recall
+func Passed(quality int) bool {
+	return quality >= passingQuality
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+)
+
+func (r *Repository) CreateAttachment(ctx context.Context, userID uuid.UUID, a *model.Attachment) error {
+	const q = `INSERT INTO interview_attachments (interview_id, user_id, filename, content_type, size, blob_key)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING attachment_id, created_at`
+	if err := r.db.QueryRow(ctx, q, a.InterviewID, userID, a.Filename, a.ContentType, a.Size, a.BlobKey).Scan(&a.AttachmentID, &a.CreatedAt); err != nil {
+		return fmt.Errorf("insert attachment: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) ListAttachments(ctx context.Context, userID uuid.UUID, interviewID int64) ([]model.Attachment, error) {
+	const q = `SELECT attachment_id, interview_id, filename, content_type, size, blob_key, created_at
+FROM interview_attachments
+WHERE interview_id = $1 AND user_id = $2
+ORDER BY attachment_id`
+
+	rows, err := r.db.Query(ctx, q, interviewID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query attachments: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.Attachment{}
+	for rows.Next() {
+		var a model.Attachment
+		if err := rows.Scan(&a.AttachmentID, &a.InterviewID, &a.Filename, &a.ContentType, &a.Size, &a.BlobKey, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan attachment: %w", err)
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// GetAttachment returns pgx.ErrNoRows when the attachment does not exist or is not the user's
+func (r *Repository) GetAttachment(ctx context.Context, userID uuid.UUID, interviewID, attachmentID int64) (*model.Attachment, error) {
+	const q = `SELECT attachment_id, interview_id, filename, content_type, size, blob_key, created_at
+FROM interview_attachments
+WHERE attachment_id = $1 AND interview_id = $2 AND user_id = $3`
+
+	var a model.Attachment
+	err := r.db.QueryRow(ctx, q, attachmentID, interviewID, userID).
+		Scan(&a.AttachmentID, &a.InterviewID, &a.Filename, &a.ContentType, &a.Size, &a.BlobKey, &a.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+)
+
+// CompanyBriefFingerprint hashes the state of a company's processed interviews and their
+// questions. The hash changes whenever an interview or question is added, edited or removed.
+func (r *Repository) CompanyBriefFingerprint(ctx context.Context, userID, companyID uuid.UUID) (string, int, error) {
+	const q = `
+SELECT COUNT(*), COALESCE(md5(string_agg(
+	i.interview_id::text || ':' || extract(epoch FROM i.updated_at)::text || ':' || (
+		SELECT COUNT(*)::text || ':' || COALESCE(MAX(extract(epoch FROM q.updated_at)), 0)::text
+		FROM questions q WHERE q.interview_id = i.interview_id
+	), ',' ORDER BY i.interview_id)), '')
+FROM interviews i
+WHERE i.user_id = $1 AND i.company_id = $2 AND i.process_status = 'success'`
+	var (
+		count       int
+		fingerprint string
+	)
+	if err := r.db.QueryRow(ctx, q, userID, companyID).Scan(&count, &fingerprint); err != nil {
+		return "", 0, fmt.Errorf("query company brief fingerprint: %w", err)
+	}
+	return fingerprint, count, nil
+}
+
+func (r *Repository) GetCompanyBrief(ctx context.Context, companyID uuid.UUID) (*model.CompanyBrief, error) {
+	const q = `SELECT company_id, brief, fingerprint, interview_count, generated_at FROM company_briefs WHERE company_id = $1`
+	var b model.CompanyBrief
+	if err := r.db.QueryRow(ctx, q, companyID).Scan(&b.CompanyID, &b.Brief, &b.Fingerprint, &b.InterviewCount, &b.GeneratedAt); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (r *Repository) SaveCompanyBrief(ctx context.Context, userID uuid.UUID, b *model.CompanyBrief) error {
+	const q = `
+INSERT INTO company_briefs (company_id, user_id, brief, fingerprint, interview_count, generated_at)
+VALUES ($1, $2, $3, $4, $5, NOW())
+ON CONFLICT (company_id) DO UPDATE SET
+	brief = EXCLUDED.brief,
+	fingerprint = EXCLUDED.fingerprint,
+	interview_count = EXCLUDED.interview_count,
+	generated_at = EXCLUDED.generated_at
+RETURNING generated_at`
+	if err := r.db.QueryRow(ctx, q, b.CompanyID, userID, b.Brief, b.Fingerprint, b.InterviewCount).Scan(&b.GeneratedAt); err != nil {
+		return fmt.Errorf("save company brief: %w", err)
+	}
+	return nil
+}
+
+// ListBriefInterviews returns the processed interviews of a company with their questions, oldest first
+func (r *Repository) ListBriefInterviews(ctx context.Context, userID, companyID uuid.UUID) ([]model.BriefInterview, error) {
+	const q = `
+SELECT i.interview_id, i.created_at, i.position, i.no_of_round, i.location, i.raw_input,
+	COALESCE(array_agg(q.question ORDER BY q.q_id) FILTER (WHERE q.q_id IS NOT NULL), '{}'),
+	COALESCE(array_agg(q.type ORDER BY q.q_id) FILTER (WHERE q.q_id IS NOT NULL), '{}')
+FROM interviews i
+LEFT JOIN questions q ON q.interview_id = i.interview_id
+WHERE i.user_id = $1 AND i.company_id = $2 AND i.process_status = 'success'
+GROUP BY i.interview_id
+ORDER BY i.created_at ASC`
+	rows, err := r.db.Query(ctx, q, userID, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("query brief interviews: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.BriefInterview
+	for rows.Next() {
+		var (
+			bi        model.BriefInterview
+			questions []string
+			types     []string
+		)
+		if err := rows.Scan(&bi.InterviewID, &bi.CreatedAt, &bi.Position, &bi.NoOfRound, &bi.Location, &bi.RawInput, &questions, &types); err != nil {
+			return nil, fmt.Errorf("scan brief interview: %w", err)
+		}
+		for i := range questions {
+			bi.Questions = append(bi.Questions, model.BriefQuestion{Question: questions[i], Type: types[i]})
+		}
+		out = append(out, bi)
+	}
+	return out, nil
+}
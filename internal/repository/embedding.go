@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/abhishek622/interviewMin/internal/embedding"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// embeddingDocs is the text embedded for each interview and question of user $1
+const embeddingDocs = `
+WITH docs AS (
+	SELECT 'interview' AS type, i.interview_id AS id,
+		concat_ws(E'\n', i.metadata->>'title', i.position, c.name, i.location, i.metadata->>'full_experience') AS content
+	FROM interviews i
+	INNER JOIN companies c ON c.company_id = i.company_id
+	WHERE i.user_id = $1 AND i.process_status = 'success'
+	UNION ALL
+	SELECT 'question', q.q_id, q.question
+	FROM questions q
+	INNER JOIN interviews i ON i.interview_id = q.interview_id
+	WHERE i.user_id = $1
+)`
+
+// ListStaleEmbeddings returns interviews and questions of the user that have no embedding
+// from the given provider, or whose text changed since they were embedded
+func (r *Repository) ListStaleEmbeddings(ctx context.Context, userID uuid.UUID, provider string, limit int) ([]model.EmbeddingDoc, error) {
+	const q = embeddingDocs + `
+SELECT d.type, d.id, d.content, md5(d.content)
+FROM docs d
+LEFT JOIN interview_embeddings ie ON d.type = 'interview' AND ie.interview_id = d.id
+LEFT JOIN question_embeddings qe ON d.type = 'question' AND qe.q_id = d.id
+WHERE COALESCE(ie.provider, qe.provider) IS DISTINCT FROM $2
+	OR COALESCE(ie.content_hash, qe.content_hash) IS DISTINCT FROM md5(d.content)
+LIMIT $3`
+	rows, err := r.db.Query(ctx, q, userID, provider, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query stale embeddings: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.EmbeddingDoc
+	for rows.Next() {
+		var d model.EmbeddingDoc
+		if err := rows.Scan(&d.Type, &d.ID, &d.Content, &d.ContentHash); err != nil {
+			return nil, fmt.Errorf("scan stale embedding: %w", err)
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// SaveEmbeddings stores one vector per doc, replacing any previous embedding
+func (r *Repository) SaveEmbeddings(ctx context.Context, userID uuid.UUID, provider string, docs []model.EmbeddingDoc, vectors [][]float32) error {
+	const (
+		qInterview = `
+INSERT INTO interview_embeddings (interview_id, user_id, provider, embedding, content_hash, updated_at)
+VALUES ($1, $2, $3, $4, $5, NOW())
+ON CONFLICT (interview_id) DO UPDATE SET
+	provider = EXCLUDED.provider, embedding = EXCLUDED.embedding,
+	content_hash = EXCLUDED.content_hash, updated_at = EXCLUDED.updated_at`
+		qQuestion = `
+INSERT INTO question_embeddings (q_id, user_id, provider, embedding, content_hash, updated_at)
+VALUES ($1, $2, $3, $4, $5, NOW())
+ON CONFLICT (q_id) DO UPDATE SET
+	provider = EXCLUDED.provider, embedding = EXCLUDED.embedding,
+	content_hash = EXCLUDED.content_hash, updated_at = EXCLUDED.updated_at`
+	)
+
+	batch := &pgx.Batch{}
+	for i, d := range docs {
+		q := qQuestion
+		if d.Type == model.SearchResultInterview {
+			q = qInterview
+		}
+		batch.Queue(q, d.ID, userID, provider, vectors[i], d.ContentHash)
+	}
+
+	br := r.db.SendBatch(ctx, batch)
+	defer br.Close()
+	for range docs {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("save embedding: %w", err)
+		}
+	}
+	return nil
+}
+
+// hasPgvector reports whether the pgvector extension is installed, checked once
+func (r *Repository) hasPgvector(ctx context.Context) bool {
+	r.vectorOnce.Do(func() {
+		const q = `SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'vector')`
+		if err := r.db.QueryRow(ctx, q).Scan(&r.vectorEnabled); err != nil {
+			r.vectorEnabled = false
+		}
+	})
+	return r.vectorEnabled
+}
+
+// SemanticSearch returns the interviews and questions of the user closest to the query vector.
+// With pgvector the distance is computed in the database, otherwise the vectors are compared here.
+func (r *Repository) SemanticSearch(ctx context.Context, userID uuid.UUID, provider string, query []float32, types []model.SearchResultType, limit int) ([]model.SemanticHit, error) {
+	var typeFilter []string
+	for _, t := range types {
+		typeFilter = append(typeFilter, string(t))
+	}
+
+	const vectors = `
+WITH vectors AS (
+	SELECT 'interview' AS type, interview_id AS id, embedding FROM interview_embeddings WHERE user_id = $1 AND provider = $2
+	UNION ALL
+	SELECT 'question', q_id, embedding FROM question_embeddings WHERE user_id = $1 AND provider = $2
+)`
+
+	if r.hasPgvector(ctx) {
+		const q = vectors + `
+SELECT type, id, (1 - (embedding::vector <=> $3::real[]::vector))::real AS similarity
+FROM vectors
+WHERE ($4::text[] IS NULL OR type = ANY($4)) AND array_length(embedding, 1) = array_length($3::real[], 1)
+ORDER BY embedding::vector <=> $3::real[]::vector
+LIMIT $5`
+		rows, err := r.db.Query(ctx, q, userID, provider, query, typeFilter, limit)
+		if err != nil {
+			return nil, fmt.Errorf("query semantic search: %w", err)
+		}
+		defer rows.Close()
+
+		var out []model.SemanticHit
+		for rows.Next() {
+			var h model.SemanticHit
+			if err := rows.Scan(&h.Type, &h.ID, &h.Similarity); err != nil {
+				return nil, fmt.Errorf("scan semantic hit: %w", err)
+			}
+			out = append(out, h)
+		}
+		return out, rows.Err()
+	}
+
+	const q = vectors + `SELECT type, id, embedding FROM vectors WHERE $3::text[] IS NULL OR type = ANY($3)`
+	rows, err := r.db.Query(ctx, q, userID, provider, typeFilter)
+	if err != nil {
+		return nil, fmt.Errorf("query embeddings: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.SemanticHit
+	for rows.Next() {
+		var (
+			h   model.SemanticHit
+			vec []float32
+		)
+		if err := rows.Scan(&h.Type, &h.ID, &vec); err != nil {
+			return nil, fmt.Errorf("scan embedding: %w", err)
+		}
+		h.Similarity = embedding.Cosine(query, vec)
+		out = append(out, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Similarity > out[j].Similarity })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// SearchResultDetails loads display fields for interviews and questions found by semantic search
+func (r *Repository) SearchResultDetails(ctx context.Context, userID uuid.UUID, interviewIDs, qIDs []int64) (map[int64]model.SearchResult, map[int64]model.SearchResult, error) {
+	const q = `
+SELECT 'interview', i.interview_id, NULL::bigint, c.company_id, c.name,
+	COALESCE(NULLIF(i.metadata->>'title', ''), NULLIF(i.position, ''), c.name),
+	left(COALESCE(NULLIF(i.metadata->>'full_experience', ''), i.raw_input), 200), i.created_at
+FROM interviews i
+INNER JOIN companies c ON c.company_id = i.company_id
+WHERE i.user_id = $1 AND i.interview_id = ANY($2)
+UNION ALL
+SELECT 'question', q.interview_id, q.q_id, c.company_id, c.name, q.question, left(q.question, 200), q.created_at
+FROM questions q
+INNER JOIN interviews i ON i.interview_id = q.interview_id
+INNER JOIN companies c ON c.company_id = i.company_id
+WHERE i.user_id = $1 AND q.q_id = ANY($3)`
+	rows, err := r.db.Query(ctx, q, userID, interviewIDs, qIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query search result details: %w", err)
+	}
+	defer rows.Close()
+
+	interviews := map[int64]model.SearchResult{}
+	questions := map[int64]model.SearchResult{}
+	for rows.Next() {
+		var res model.SearchResult
+		if err := rows.Scan(&res.Type, &res.InterviewID, &res.QID, &res.CompanyID, &res.CompanyName,
+			&res.Title, &res.Snippet, &res.CreatedAt,
+		); err != nil {
+			return nil, nil, fmt.Errorf("scan search result details: %w", err)
+		}
+		if res.QID != nil {
+			questions[*res.QID] = res
+		} else {
+			interviews[*res.InterviewID] = res
+		}
+	}
+	return interviews, questions, rows.Err()
+}
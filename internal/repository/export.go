@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+)
+
+// ExportData loads every company, interview and question of the user for an export
+func (r *Repository) ExportData(ctx context.Context, userID uuid.UUID) (*model.ExportArchive, error) {
+	archive := &model.ExportArchive{
+		Version:    model.ExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Companies:  []model.ExportCompany{},
+		Interviews: []model.ExportInterview{},
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT name, slug FROM companies WHERE user_id = $1 ORDER BY name`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query export companies: %w", err)
+	}
+	for rows.Next() {
+		var c model.ExportCompany
+		if err := rows.Scan(&c.Name, &c.Slug); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan export company: %w", err)
+		}
+		archive.Companies = append(archive.Companies, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	const qInterviews = `
+SELECT i.interview_id, c.name, i.source, COALESCE(i.metadata->>'title', ''), i.position, i.no_of_round, i.location,
+	i.language, i.raw_input, COALESCE(i.metadata->>'full_experience', ''), i.metadata, i.created_at,
+	i.source_url, i.canonical_url, i.fetched_at, i.source_published_at, i.content_hash,
+	COALESCE((SELECT array_agg(t.name ORDER BY t.name) FROM interview_tags it
+		INNER JOIN tags t ON t.tag_id = it.tag_id WHERE it.interview_id = i.interview_id), '{}')
+FROM interviews i
+INNER JOIN companies c ON c.company_id = i.company_id
+WHERE i.user_id = $1
+ORDER BY i.created_at ASC, i.interview_id ASC`
+	rows, err = r.db.Query(ctx, qInterviews, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query export interviews: %w", err)
+	}
+	index := map[int64]int{}
+	for rows.Next() {
+		var e model.ExportInterview
+		if err := rows.Scan(&e.InterviewID, &e.Company, &e.Source, &e.Title, &e.Position, &e.NoOfRound, &e.Location,
+			&e.Language, &e.RawInput, &e.FullExperience, &e.Metadata, &e.CreatedAt,
+			&e.SourceURL, &e.CanonicalURL, &e.FetchedAt, &e.SourcePublishedAt, &e.ContentHash, &e.Tags,
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan export interview: %w", err)
+		}
+		e.Questions = []model.ExportQuestion{}
+		index[e.InterviewID] = len(archive.Interviews)
+		archive.Interviews = append(archive.Interviews, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	const qQuestions = `
+SELECT q.interview_id, q.q_id, q.question, q.type,
+	COALESCE((SELECT array_agg(t.name ORDER BY t.name) FROM question_tags qt
+		INNER JOIN tags t ON t.tag_id = qt.tag_id WHERE qt.q_id = q.q_id), '{}'),
+	CASE WHEN q.lc_status = 'confirmed' THEN q.lc_slug END,
+	n.content
+FROM questions q
+INNER JOIN interviews i ON i.interview_id = q.interview_id
+LEFT JOIN question_notes n ON n.q_id = q.q_id
+WHERE i.user_id = $1
+ORDER BY q.q_id ASC`
+	rows, err = r.db.Query(ctx, qQuestions, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query export questions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			interviewID int64
+			q           model.ExportQuestion
+		)
+		if err := rows.Scan(&interviewID, &q.QID, &q.Question, &q.Type, &q.Tags, &q.LeetcodeSlug, &q.Note); err != nil {
+			return nil, fmt.Errorf("scan export question: %w", err)
+		}
+		if i, ok := index[interviewID]; ok {
+			archive.Interviews[i].Questions = append(archive.Interviews[i].Questions, q)
+		}
+	}
+	return archive, rows.Err()
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/abhishek622/interviewMin/pkg"
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const importJobColumns = `job_id, user_id, format, filename, status, dry_run, extract_questions, total_rows,
+	processed_rows, created_count, error_count, errors, error, created_at, finished_at`
+
+func scanImportJob(row pgx.Row) (*model.ImportJob, error) {
+	var j model.ImportJob
+	err := row.Scan(&j.JobID, &j.UserID, &j.Format, &j.Filename, &j.Status, &j.DryRun, &j.ExtractQuestions, &j.TotalRows,
+		&j.ProcessedRows, &j.CreatedCount, &j.ErrorCount, &j.Errors, &j.Error, &j.CreatedAt, &j.FinishedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+func (r *Repository) CreateImportJob(ctx context.Context, job *model.ImportJob) error {
+	if job.Errors == nil {
+		job.Errors = []model.ImportRowError{}
+	}
+	const q = `
+INSERT INTO import_jobs (user_id, format, filename, status, dry_run, extract_questions, total_rows, error_count, errors)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING job_id, created_at`
+	err := r.db.QueryRow(ctx, q, job.UserID, job.Format, job.Filename, job.Status, job.DryRun, job.ExtractQuestions,
+		job.TotalRows, job.ErrorCount, job.Errors,
+	).Scan(&job.JobID, &job.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert import job: %w", err)
+	}
+	return nil
+}
+
+// UpdateImportJobProgress stores the counters and errors of a running job
+func (r *Repository) UpdateImportJobProgress(ctx context.Context, job *model.ImportJob) error {
+	const q = `
+UPDATE import_jobs SET status = $1, processed_rows = $2, created_count = $3, error_count = $4, errors = $5
+WHERE job_id = $6`
+	if _, err := r.db.Exec(ctx, q, job.Status, job.ProcessedRows, job.CreatedCount, job.ErrorCount, job.Errors, job.JobID); err != nil {
+		return fmt.Errorf("update import job: %w", err)
+	}
+	return nil
+}
+
+// FinishImportJob stores the final state of a job, jobErr is set when the whole job failed
+func (r *Repository) FinishImportJob(ctx context.Context, job *model.ImportJob, jobErr *string) error {
+	const q = `
+UPDATE import_jobs SET status = $1, processed_rows = $2, created_count = $3, error_count = $4, errors = $5,
+	error = $6, finished_at = NOW()
+WHERE job_id = $7`
+	if _, err := r.db.Exec(ctx, q, job.Status, job.ProcessedRows, job.CreatedCount, job.ErrorCount, job.Errors, jobErr, job.JobID); err != nil {
+		return fmt.Errorf("finish import job: %w", err)
+	}
+	return nil
+}
+
+// FailInterruptedImportJobs fails every queued or running job and returns how many there were
+func (r *Repository) FailInterruptedImportJobs(ctx context.Context, reason string) (int64, error) {
+	const q = `
+UPDATE import_jobs SET status = $1, error = $2, finished_at = NOW()
+WHERE status IN ($3, $4)`
+	tag, err := r.db.Exec(ctx, q, model.ImportJobFailed, reason, model.ImportJobQueued, model.ImportJobRunning)
+	if err != nil {
+		return 0, fmt.Errorf("fail interrupted import jobs: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *Repository) GetImportJob(ctx context.Context, userID uuid.UUID, jobID int64) (*model.ImportJob, error) {
+	q := `SELECT ` + importJobColumns + ` FROM import_jobs WHERE job_id = $1 AND user_id = $2`
+	return scanImportJob(r.db.QueryRow(ctx, q, jobID, userID))
+}
+
+func (r *Repository) ListImportJobs(ctx context.Context, userID uuid.UUID, limit, offset int) ([]model.ImportJob, int, error) {
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM import_jobs WHERE user_id = $1`, userID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count import jobs: %w", err)
+	}
+
+	q := `SELECT ` + importJobColumns + ` FROM import_jobs WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
+	rows, err := r.db.Query(ctx, q, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query import jobs: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.ImportJob{}
+	for rows.Next() {
+		j, err := scanImportJob(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan import job: %w", err)
+		}
+		out = append(out, *j)
+	}
+	return out, total, rows.Err()
+}
+
+// ImportInterview creates an interview with its questions, tags and notes in one transaction
+// and returns the ids of the created questions in the order of iv.Questions
+func (r *Repository) ImportInterview(ctx context.Context, userID, companyID uuid.UUID, iv *model.ExportInterview) (int64, []int64, error) {
+	metadata := map[string]interface{}{}
+	for k, v := range iv.Metadata {
+		metadata[k] = v
+	}
+	metadata["title"] = iv.Title
+	metadata["full_experience"] = iv.FullExperience
+	metadata["imported"] = true
+
+	rawInput := iv.RawInput
+	if rawInput == "" {
+		rawInput = iv.FullExperience
+	}
+	interview := &model.Interview{RawInput: rawInput, Language: iv.Language, Metadata: metadata}
+
+	var interviewID int64
+	qIDs := make([]int64, len(iv.Questions))
+	err := r.execTx(ctx, func(tx pgx.Tx) error {
+		// exports keep the original creation date and provenance
+		var createdAt *time.Time
+		if !iv.CreatedAt.IsZero() {
+			createdAt = &iv.CreatedAt
+		}
+		p := iv.Provenance
+		const qInterview = `
+INSERT INTO interviews (
+	company_id, user_id, source, raw_input, process_status, position, no_of_round, location, metadata, language,
+	source_url, canonical_url, fetched_at, source_published_at, content_hash, created_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, COALESCE($16, NOW()))
+RETURNING interview_id`
+		err := tx.QueryRow(ctx, qInterview,
+			companyID, userID, iv.Source, rawInput, model.ProcessStatusSuccess, iv.Position, iv.NoOfRound, iv.Location, metadata,
+			interviewLanguage(interview),
+			p.SourceURL, p.CanonicalURL, p.FetchedAt, p.SourcePublishedAt, p.ContentHash, createdAt,
+		).Scan(&interviewID)
+		if err != nil {
+			if isCanonicalURLConflict(err) {
+				return ErrInterviewExists
+			}
+			return fmt.Errorf("insert interview: %w", err)
+		}
+
+		tagIDs := map[string]int64{}
+		ensureTag := func(name string) (int64, error) {
+			slug := pkg.GenerateSlug(name)
+			if id, ok := tagIDs[slug]; ok {
+				return id, nil
+			}
+			var id int64
+			const qTag = `
+INSERT INTO tags (user_id, name, slug) VALUES ($1, $2, $3)
+ON CONFLICT (user_id, slug) DO UPDATE SET name = tags.name
+RETURNING tag_id`
+			if err := tx.QueryRow(ctx, qTag, userID, strings.TrimSpace(name), slug).Scan(&id); err != nil {
+				return 0, fmt.Errorf("ensure tag %q: %w", name, err)
+			}
+			tagIDs[slug] = id
+			return id, nil
+		}
+
+		for _, name := range iv.Tags {
+			tagID, err := ensureTag(name)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec(ctx, `INSERT INTO interview_tags (interview_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, interviewID, tagID); err != nil {
+				return fmt.Errorf("insert interview tag: %w", err)
+			}
+		}
+
+		for i, question := range iv.Questions {
+			const qQuestion = `INSERT INTO questions (interview_id, question, "type") VALUES ($1, $2, $3) RETURNING q_id`
+			if err := tx.QueryRow(ctx, qQuestion, interviewID, question.Question, question.Type).Scan(&qIDs[i]); err != nil {
+				return fmt.Errorf("insert question %d: %w", i, err)
+			}
+
+			for _, name := range question.Tags {
+				tagID, err := ensureTag(name)
+				if err != nil {
+					return err
+				}
+				if _, err := tx.Exec(ctx, `INSERT INTO question_tags (q_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, qIDs[i], tagID); err != nil {
+					return fmt.Errorf("insert question tag: %w", err)
+				}
+			}
+
+			if question.Note != nil && *question.Note != "" {
+				const qNote = `INSERT INTO question_notes (q_id, user_id, content, snippets, revision) VALUES ($1, $2, $3, '[]', 1)`
+				if _, err := tx.Exec(ctx, qNote, qIDs[i], userID, *question.Note); err != nil {
+					return fmt.Errorf("insert question note: %w", err)
+				}
+				const qRevision = `INSERT INTO question_note_revisions (q_id, revision, content, snippets) VALUES ($1, 1, $2, '[]')`
+				if _, err := tx.Exec(ctx, qRevision, qIDs[i], *question.Note); err != nil {
+					return fmt.Errorf("insert note revision: %w", err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return interviewID, qIDs, nil
+}
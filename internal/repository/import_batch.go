@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ImportedURLs returns the interviews already imported from the given canonical URLs, keyed by URL
+func (r *Repository) ImportedURLs(ctx context.Context, userID uuid.UUID, canonicalURLs []string) (map[string]int64, error) {
+	out := map[string]int64{}
+	if len(canonicalURLs) == 0 {
+		return out, nil
+	}
+
+	const q = `SELECT canonical_url, interview_id FROM interviews WHERE user_id = $1 AND canonical_url = ANY($2)`
+	rows, err := r.db.Query(ctx, q, userID, canonicalURLs)
+	if err != nil {
+		return nil, fmt.Errorf("query imported urls: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			url         string
+			interviewID int64
+		)
+		if err := rows.Scan(&url, &interviewID); err != nil {
+			return nil, fmt.Errorf("scan imported url: %w", err)
+		}
+		out[url] = interviewID
+	}
+	return out, rows.Err()
+}
+
+// CreateImportBatch stores a batch with its items, filling in their ids
+func (r *Repository) CreateImportBatch(ctx context.Context, userID uuid.UUID, items []model.ImportBatchItem) (int64, error) {
+	var batchID int64
+	err := r.execTx(ctx, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, `INSERT INTO import_batches (user_id) VALUES ($1) RETURNING batch_id`, userID).Scan(&batchID); err != nil {
+			return fmt.Errorf("insert import batch: %w", err)
+		}
+
+		const q = `
+INSERT INTO import_batch_items (batch_id, user_id, url, canonical_url, source, status, interview_id, error)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING item_id, updated_at`
+		for i := range items {
+			it := &items[i]
+			err := tx.QueryRow(ctx, q, batchID, userID, it.URL, it.CanonicalURL, it.Source, it.Status, it.InterviewID, it.Error).
+				Scan(&it.ItemID, &it.UpdatedAt)
+			if err != nil {
+				return fmt.Errorf("insert import batch item: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return batchID, nil
+}
+
+func (r *Repository) UpdateImportBatchItem(ctx context.Context, itemID int64, status model.BatchItemStatus, interviewID *int64, errMsg *string) error {
+	const q = `UPDATE import_batch_items SET status = $1, interview_id = COALESCE($2, interview_id), error = $3 WHERE item_id = $4`
+	if _, err := r.db.Exec(ctx, q, status, interviewID, errMsg, itemID); err != nil {
+		return fmt.Errorf("update import batch item: %w", err)
+	}
+	return nil
+}
+
+// FailInterruptedImportBatches fails the items left queued or processing and finishes
+// every unfinished batch, it returns how many items were failed
+func (r *Repository) FailInterruptedImportBatches(ctx context.Context, reason string) (int64, error) {
+	var failed int64
+	err := r.execTx(ctx, func(tx pgx.Tx) error {
+		const qItems = `UPDATE import_batch_items SET status = $1, error = $2 WHERE status IN ($3, $4)`
+		tag, err := tx.Exec(ctx, qItems, model.BatchItemFailed, reason, model.BatchItemQueued, model.BatchItemProcessing)
+		if err != nil {
+			return fmt.Errorf("fail interrupted batch items: %w", err)
+		}
+		failed = tag.RowsAffected()
+		if _, err := tx.Exec(ctx, `UPDATE import_batches SET finished_at = NOW() WHERE finished_at IS NULL`); err != nil {
+			return fmt.Errorf("finish interrupted batches: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return failed, nil
+}
+
+func (r *Repository) FinishImportBatch(ctx context.Context, batchID int64) error {
+	if _, err := r.db.Exec(ctx, `UPDATE import_batches SET finished_at = NOW() WHERE batch_id = $1`, batchID); err != nil {
+		return fmt.Errorf("finish import batch: %w", err)
+	}
+	return nil
+}
+
+// GetImportBatch returns a batch of the user with the progress of every URL
+func (r *Repository) GetImportBatch(ctx context.Context, userID uuid.UUID, batchID int64) (*model.ImportBatch, error) {
+	b := &model.ImportBatch{
+		BatchID: batchID,
+		Counts:  map[model.BatchItemStatus]int{},
+		Items:   []model.ImportBatchItem{},
+	}
+	const qBatch = `SELECT created_at, finished_at FROM import_batches WHERE batch_id = $1 AND user_id = $2`
+	if err := r.db.QueryRow(ctx, qBatch, batchID, userID).Scan(&b.CreatedAt, &b.FinishedAt); err != nil {
+		return nil, err
+	}
+
+	const qItems = `
+SELECT item_id, url, canonical_url, source, status, interview_id, error, updated_at
+FROM import_batch_items WHERE batch_id = $1 ORDER BY item_id`
+	rows, err := r.db.Query(ctx, qItems, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("query import batch items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var it model.ImportBatchItem
+		if err := rows.Scan(&it.ItemID, &it.URL, &it.CanonicalURL, &it.Source, &it.Status, &it.InterviewID, &it.Error, &it.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan import batch item: %w", err)
+		}
+		b.Counts[it.Status]++
+		b.Items = append(b.Items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	b.Total = len(b.Items)
+	b.Done = b.Counts[model.BatchItemQueued] == 0 && b.Counts[model.BatchItemProcessing] == 0
+	return b, nil
+}
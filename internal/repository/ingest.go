@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+)
+
+// GetIngestAddress returns pgx.ErrNoRows when the user has no address yet
+func (r *Repository) GetIngestAddress(ctx context.Context, userID uuid.UUID) (*model.IngestAddress, error) {
+	const q = `SELECT token, created_at FROM ingest_addresses WHERE user_id = $1`
+	var a model.IngestAddress
+	if err := r.db.QueryRow(ctx, q, userID).Scan(&a.Token, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// SetIngestToken creates the user's address or replaces its token
+func (r *Repository) SetIngestToken(ctx context.Context, userID uuid.UUID, token string) (*model.IngestAddress, error) {
+	const q = `INSERT INTO ingest_addresses (user_id, token)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET token = EXCLUDED.token, created_at = NOW()
+RETURNING token, created_at`
+	var a model.IngestAddress
+	if err := r.db.QueryRow(ctx, q, userID, token).Scan(&a.Token, &a.CreatedAt); err != nil {
+		return nil, fmt.Errorf("upsert ingest address: %w", err)
+	}
+	return &a, nil
+}
+
+// GetUserIDByIngestToken returns pgx.ErrNoRows for unknown tokens
+func (r *Repository) GetUserIDByIngestToken(ctx context.Context, token string) (uuid.UUID, error) {
+	const q = `SELECT user_id FROM ingest_addresses WHERE token = $1`
+	var userID uuid.UUID
+	if err := r.db.QueryRow(ctx, q, token).Scan(&userID); err != nil {
+		return uuid.Nil, err
+	}
+	return userID, nil
+}
+
+// MarkEmailIngested records a message id and reports false when it was already recorded
+func (r *Repository) MarkEmailIngested(ctx context.Context, userID uuid.UUID, messageID string) (bool, error) {
+	const q = `INSERT INTO ingested_emails (user_id, message_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	tag, err := r.db.Exec(ctx, q, userID, messageID)
+	if err != nil {
+		return false, fmt.Errorf("insert ingested email: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ForgetIngestedEmail removes a message id so the message is ingested again when it is resent
+func (r *Repository) ForgetIngestedEmail(ctx context.Context, userID uuid.UUID, messageID string) error {
+	const q = `DELETE FROM ingested_emails WHERE user_id = $1 AND message_id = $2`
+	if _, err := r.db.Exec(ctx, q, userID, messageID); err != nil {
+		return fmt.Errorf("delete ingested email: %w", err)
+	}
+	return nil
+}
@@ -3,38 +3,150 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/abhishek622/interviewMin/pkg"
 	"github.com/abhishek622/interviewMin/pkg/model"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// interviewLanguage returns the language an interview's search document is indexed in
+func interviewLanguage(interview *model.Interview) string {
+	if interview.Language != "" {
+		return interview.Language
+	}
+	if experience, ok := interview.Metadata["full_experience"].(string); ok && experience != "" {
+		return pkg.DetectLanguage(experience)
+	}
+	return pkg.DetectLanguage(interview.RawInput)
+}
+
+// ErrInterviewExists is returned when the user already imported an interview from the same URL
+var ErrInterviewExists = errors.New("interview already imported")
+
+// isCanonicalURLConflict tells whether err is a violation of the per user canonical URL index
+func isCanonicalURLConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_interviews_user_canonical_url"
+}
+
 func (r *Repository) CreateInterview(ctx context.Context, interview *model.Interview) (*int64, error) {
 	const q = `
 INSERT INTO interviews (
-	 company_id, user_id, source, raw_input, process_status, metadata
-) VALUES ($1, $2, $3, $4, $5, $6) RETURNING interview_id
+	 company_id, user_id, source, raw_input, process_status, metadata, language,
+	 source_url, canonical_url, fetched_at, source_published_at, content_hash
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING interview_id
 `
+	p := interview.Provenance
 	row := r.db.QueryRow(ctx, q,
 		interview.CompanyID, interview.UserID, interview.Source, interview.RawInput, interview.ProcessStatus, interview.Metadata,
+		interviewLanguage(interview),
+		p.SourceURL, p.CanonicalURL, p.FetchedAt, p.SourcePublishedAt, p.ContentHash,
 	)
 	var interviewID int64
 	if err := row.Scan(&interviewID); err != nil {
+		if isCanonicalURLConflict(err) {
+			return nil, ErrInterviewExists
+		}
 		return nil, fmt.Errorf("insert interview: %w", err)
 	}
 	return &interviewID, nil
 }
 
+// FillQueuedInterview stores the result of importing a queued interview in its row
+func (r *Repository) FillQueuedInterview(ctx context.Context, interviewID int64, interview *model.Interview) error {
+	const q = `
+UPDATE interviews SET
+	company_id = $1, raw_input = $2, process_status = $3, process_error = $4, metadata = $5,
+	position = $6, no_of_round = $7, location = $8, language = $9,
+	source_url = $10, canonical_url = $11, fetched_at = $12, source_published_at = $13, content_hash = $14
+WHERE interview_id = $15 AND process_status = $16`
+	p := interview.Provenance
+	tag, err := r.db.Exec(ctx, q,
+		interview.CompanyID, interview.RawInput, interview.ProcessStatus, interview.ProcessError, interview.Metadata,
+		interview.Position, interview.NoOfRound, interview.Location, interviewLanguage(interview),
+		p.SourceURL, p.CanonicalURL, p.FetchedAt, p.SourcePublishedAt, p.ContentHash,
+		interviewID, model.ProcessStatusQueued,
+	)
+	if err != nil {
+		if isCanonicalURLConflict(err) {
+			return ErrInterviewExists
+		}
+		return fmt.Errorf("fill queued interview: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("queued interview %d not found", interviewID)
+	}
+	return nil
+}
+
+// FailQueuedInterview fails a queued interview whose post could not be fetched, its
+// canonical URL is cleared so the post can be imported again
+func (r *Repository) FailQueuedInterview(ctx context.Context, interviewID int64, reason string) error {
+	const q = `
+UPDATE interviews SET process_status = $1, process_error = $2, canonical_url = NULL
+WHERE interview_id = $3 AND process_status = $4`
+	if _, err := r.db.Exec(ctx, q, model.ProcessStatusFailed, reason, interviewID, model.ProcessStatusQueued); err != nil {
+		return fmt.Errorf("fail queued interview: %w", err)
+	}
+	return nil
+}
+
+// FailQueuedInterviews fails every interview still queued and returns how many there were.
+// Their canonical URL is cleared so the posts can be imported again.
+func (r *Repository) FailQueuedInterviews(ctx context.Context, reason string) (int64, error) {
+	const q = `
+UPDATE interviews SET process_status = $1, process_error = $2, canonical_url = NULL
+WHERE process_status = $3`
+	tag, err := r.db.Exec(ctx, q, model.ProcessStatusFailed, reason, model.ProcessStatusQueued)
+	if err != nil {
+		return 0, fmt.Errorf("fail queued interviews: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// DetectPendingLanguages detects the language of up to limit interviews whose language
+// was never detected and returns how many it updated
+func (r *Repository) DetectPendingLanguages(ctx context.Context, limit int) (int, error) {
+	rows, err := r.db.Query(ctx, `SELECT interview_id, raw_input, metadata FROM interviews WHERE language = '' LIMIT $1`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("query pending languages: %w", err)
+	}
+	var pending []model.Interview
+	for rows.Next() {
+		var iv model.Interview
+		if err := rows.Scan(&iv.InterviewID, &iv.RawInput, &iv.Metadata); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan pending language: %w", err)
+		}
+		pending = append(pending, iv)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("query pending languages: %w", err)
+	}
+
+	for i := range pending {
+		iv := &pending[i]
+		if _, err := r.db.Exec(ctx, `UPDATE interviews SET language = $1 WHERE interview_id = $2`, interviewLanguage(iv), iv.InterviewID); err != nil {
+			return i, fmt.Errorf("set interview language: %w", err)
+		}
+	}
+	return len(pending), nil
+}
+
 func (r *Repository) CreateFullInterview(ctx context.Context, interview *model.Interview) (*int64, error) {
 	const q = `
 INSERT INTO interviews (
-	 company_id, user_id, source, raw_input, process_status, position, no_of_round, location, metadata
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING interview_id
+	 company_id, user_id, source, raw_input, process_status, position, no_of_round, location, metadata, language
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING interview_id
 `
 	row := r.db.QueryRow(ctx, q,
 		interview.CompanyID, interview.UserID, interview.Source, interview.RawInput, interview.ProcessStatus, interview.Position, interview.NoOfRound, interview.Location, interview.Metadata,
+		interviewLanguage(interview),
 	)
 	var interviewID int64
 	if err := row.Scan(&interviewID); err != nil {
@@ -48,6 +160,8 @@ func (r *Repository) UpdateInterview(ctx context.Context, interviewID int64, upd
 		"process_status": true, "process_error": true,
 		"position": true, "source": true, "no_of_round": true,
 		"location": true, "metadata": true, "company_id": true,
+		"language": true, "raw_input": true,
+		"fetched_at": true, "source_published_at": true, "content_hash": true,
 	}
 
 	query := "UPDATE interviews SET "
@@ -88,14 +202,16 @@ func (r *Repository) GetInterviewByID(ctx context.Context, interviewID int64) (*
 	const q = `
 SELECT 
 	interview_id, user_id, company_id, source, raw_input, process_status,
-	process_error, position, no_of_round, location, metadata,
-	created_at FROM interviews WHERE interview_id = $1
+	process_error, position, no_of_round, location, language, metadata,
+	created_at, source_url, canonical_url, fetched_at, source_published_at, content_hash
+FROM interviews WHERE interview_id = $1
 `
 	var e model.InterviewRes
 	row := r.db.QueryRow(ctx, q, interviewID)
 	err := row.Scan(
 		&e.InterviewID, &e.UserID, &e.CompanyID, &e.Source, &e.RawInput, &e.ProcessStatus,
-		&e.ProcessError, &e.Position, &e.NoOfRound, &e.Location, &e.Metadata, &e.CreatedAt,
+		&e.ProcessError, &e.Position, &e.NoOfRound, &e.Location, &e.Language, &e.Metadata, &e.CreatedAt,
+		&e.SourceURL, &e.CanonicalURL, &e.FetchedAt, &e.SourcePublishedAt, &e.ContentHash,
 	)
 	if err != nil {
 		return nil, err
@@ -103,6 +219,16 @@ SELECT
 	return &e, nil
 }
 
+// InterviewIDByCanonicalURL returns the user's interview imported from a URL
+func (r *Repository) InterviewIDByCanonicalURL(ctx context.Context, userID uuid.UUID, canonicalURL string) (int64, error) {
+	var interviewID int64
+	const q = `SELECT interview_id FROM interviews WHERE user_id = $1 AND canonical_url = $2`
+	if err := r.db.QueryRow(ctx, q, userID, canonicalURL).Scan(&interviewID); err != nil {
+		return 0, err
+	}
+	return interviewID, nil
+}
+
 func (r *Repository) ListInterviewByCompany(ctx context.Context, companyID uuid.UUID, limit, offset int, filters map[string]interface{}, search *string) ([]model.InterviewListItem, int, error) {
 	// Base Query Construction
 	whereConditions := []string{"i.company_id = $1"}
@@ -112,6 +238,13 @@ func (r *Repository) ListInterviewByCompany(ctx context.Context, companyID uuid.
 	if len(filters) > 0 {
 		for col, val := range filters {
 			fmt.Println(col, val)
+			if col == "tags" {
+				// match interviews carrying any of the given tags
+				whereConditions = append(whereConditions, fmt.Sprintf("EXISTS (SELECT 1 FROM interview_tags it WHERE it.interview_id = i.interview_id AND it.tag_id = ANY($%d))", argIndex))
+				args = append(args, val)
+				argIndex++
+				continue
+			}
 			whereConditions = append(whereConditions, fmt.Sprintf("i.%s = ANY($%d)", col, argIndex))
 			args = append(args, val)
 			argIndex++
@@ -119,7 +252,10 @@ func (r *Repository) ListInterviewByCompany(ctx context.Context, companyID uuid.
 	}
 
 	if search != nil && *search != "" {
-		whereConditions = append(whereConditions, fmt.Sprintf("i.search_tsv @@ plainto_tsquery('english', $%d)", argIndex))
+		// query in the owner's preferred language, or unstemmed to match interviews indexed in another language
+		whereConditions = append(whereConditions, fmt.Sprintf(`i.search_tsv @@ (
+	websearch_to_tsquery(search_config_for((SELECT u.search_language FROM users u INNER JOIN companies uc ON uc.user_id = u.user_id WHERE uc.company_id = $1)), $%d)
+	|| websearch_to_tsquery('simple', $%d))`, argIndex, argIndex))
 		args = append(args, *search)
 		argIndex++
 	}
@@ -237,6 +373,7 @@ func (r *Repository) ListInterviewStats(ctx context.Context, userID, companyID u
 	allStatuses := []string{
 		string(model.ProcessStatusSuccess),
 		string(model.ProcessStatusFailed),
+		string(model.ProcessStatusQueued),
 	}
 
 	result := &model.InterviewListStats{
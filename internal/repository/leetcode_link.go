@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+)
+
+// ListUnmatchedDSAQuestions returns the user's dsa questions that have not been run
+// through the LeetCode matcher yet, optionally limited to a single interview
+func (r *Repository) ListUnmatchedDSAQuestions(ctx context.Context, userID uuid.UUID, interviewID *int64) ([]model.QuestionRes, error) {
+	q := `
+SELECT q.q_id, q.interview_id, q.question, q.type, q.cq_id,
+	q.lc_slug, q.lc_title, q.lc_difficulty, q.lc_topics, q.lc_score, q.lc_status
+FROM questions q
+INNER JOIN interviews i ON i.interview_id = q.interview_id
+WHERE i.user_id = $1 AND q.type = 'dsa' AND q.lc_status IS NULL`
+	args := []interface{}{userID}
+	if interviewID != nil {
+		q += " AND q.interview_id = $2"
+		args = append(args, *interviewID)
+	}
+	q += " ORDER BY q.q_id ASC"
+
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query unmatched dsa questions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.QuestionRes
+	for rows.Next() {
+		qs, err := scanQuestionRes(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan unmatched dsa question: %w", err)
+		}
+		out = append(out, *qs)
+	}
+	return out, nil
+}
+
+// SetQuestionLeetcode stores a LeetCode link on a question
+func (r *Repository) SetQuestionLeetcode(ctx context.Context, qID int64, link *model.LeetcodeLink) error {
+	const q = `
+UPDATE questions SET lc_slug = $1, lc_title = $2, lc_difficulty = $3, lc_topics = $4, lc_score = $5, lc_status = $6
+WHERE q_id = $7`
+	if _, err := r.db.Exec(ctx, q, link.Slug, link.Title, link.Difficulty, link.Topics, link.Score, link.Status, qID); err != nil {
+		return fmt.Errorf("set question leetcode link: %w", err)
+	}
+	return nil
+}
+
+// ClearQuestionLeetcode removes the LeetCode link from a question and records why,
+// so the matcher does not suggest it again
+func (r *Repository) ClearQuestionLeetcode(ctx context.Context, qID int64, status model.LeetcodeLinkStatus) error {
+	const q = `
+UPDATE questions SET lc_slug = NULL, lc_title = NULL, lc_difficulty = NULL, lc_topics = NULL, lc_score = NULL, lc_status = $1
+WHERE q_id = $2`
+	if _, err := r.db.Exec(ctx, q, status, qID); err != nil {
+		return fmt.Errorf("clear question leetcode link: %w", err)
+	}
+	return nil
+}
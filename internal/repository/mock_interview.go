@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CompanyQuestionPool returns the distinct questions a company asked across the user's
+// interviews. Questions linked to the same canonical question are counted together.
+func (r *Repository) CompanyQuestionPool(ctx context.Context, userID, companyID uuid.UUID) ([]model.MockPoolQuestion, error) {
+	const q = `
+SELECT MIN(q.q_id), COALESCE(MAX(cq.question), MIN(q.question)), MIN(q.type), COUNT(*) AS times_asked
+FROM questions q
+INNER JOIN interviews i ON i.interview_id = q.interview_id
+LEFT JOIN canonical_questions cq ON cq.cq_id = q.cq_id
+WHERE i.user_id = $1 AND i.company_id = $2 AND q.type IN ('dsa', 'system_design', 'behavioral')
+GROUP BY COALESCE(q.cq_id::text, lower(q.question))
+ORDER BY times_asked DESC`
+	rows, err := r.db.Query(ctx, q, userID, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("query company question pool: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.MockPoolQuestion
+	for rows.Next() {
+		var p model.MockPoolQuestion
+		if err := rows.Scan(&p.QID, &p.Question, &p.Type, &p.TimesAsked); err != nil {
+			return nil, fmt.Errorf("scan company question pool: %w", err)
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// CreateMockInterview stores a mock interview together with its questions
+func (r *Repository) CreateMockInterview(ctx context.Context, m *model.MockInterview) error {
+	return r.execTx(ctx, func(tx pgx.Tx) error {
+		const qMock = `
+INSERT INTO mock_interviews (user_id, company_id, title, status) VALUES ($1, $2, $3, $4)
+RETURNING mock_id, created_at`
+		if err := tx.QueryRow(ctx, qMock, m.UserID, m.CompanyID, m.Title, m.Status).Scan(&m.MockID, &m.CreatedAt); err != nil {
+			return fmt.Errorf("insert mock interview: %w", err)
+		}
+
+		const qQuestion = `
+INSERT INTO mock_interview_questions (mock_id, round_no, round_name, position, question, type, q_id, times_asked)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING mq_id`
+		batch := &pgx.Batch{}
+		for _, mq := range m.Questions {
+			batch.Queue(qQuestion, m.MockID, mq.RoundNo, mq.RoundName, mq.Position, mq.Question, mq.Type, mq.QID, mq.TimesAsked)
+		}
+		br := tx.SendBatch(ctx, batch)
+		for i := range m.Questions {
+			if err := br.QueryRow().Scan(&m.Questions[i].MQID); err != nil {
+				br.Close()
+				return fmt.Errorf("insert mock interview question: %w", err)
+			}
+		}
+		return br.Close()
+	})
+}
+
+func (r *Repository) GetMockInterview(ctx context.Context, userID uuid.UUID, mockID int64) (*model.MockInterview, error) {
+	const qMock = `
+SELECT m.mock_id, m.user_id, m.company_id, c.name, m.title, m.status, m.created_at
+FROM mock_interviews m
+INNER JOIN companies c ON c.company_id = m.company_id
+WHERE m.user_id = $1 AND m.mock_id = $2`
+	var m model.MockInterview
+	err := r.db.QueryRow(ctx, qMock, userID, mockID).Scan(
+		&m.MockID, &m.UserID, &m.CompanyID, &m.CompanyName, &m.Title, &m.Status, &m.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	const qQuestions = `
+SELECT mq_id, round_no, round_name, position, question, type, q_id, times_asked, answer, feedback, score, answered_at
+FROM mock_interview_questions
+WHERE mock_id = $1
+ORDER BY round_no, position`
+	rows, err := r.db.Query(ctx, qQuestions, mockID)
+	if err != nil {
+		return nil, fmt.Errorf("query mock interview questions: %w", err)
+	}
+	defer rows.Close()
+
+	m.Questions = []model.MockInterviewQuestion{}
+	for rows.Next() {
+		var mq model.MockInterviewQuestion
+		if err := rows.Scan(&mq.MQID, &mq.RoundNo, &mq.RoundName, &mq.Position, &mq.Question, &mq.Type,
+			&mq.QID, &mq.TimesAsked, &mq.Answer, &mq.Feedback, &mq.Score, &mq.AnsweredAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan mock interview question: %w", err)
+		}
+		m.Questions = append(m.Questions, mq)
+	}
+	return &m, nil
+}
+
+func (r *Repository) ListMockInterviews(ctx context.Context, userID uuid.UUID, limit, offset int) ([]model.MockInterviewListItem, int, error) {
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM mock_interviews WHERE user_id = $1`, userID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count mock interviews: %w", err)
+	}
+
+	const q = `
+SELECT m.mock_id, m.company_id, c.name, m.title, m.status, m.created_at,
+	COUNT(mq.mq_id), COUNT(mq.answered_at), AVG(mq.score)::float8
+FROM mock_interviews m
+INNER JOIN companies c ON c.company_id = m.company_id
+LEFT JOIN mock_interview_questions mq ON mq.mock_id = m.mock_id
+WHERE m.user_id = $1
+GROUP BY m.mock_id, c.name
+ORDER BY m.created_at DESC
+LIMIT $2 OFFSET $3`
+	rows, err := r.db.Query(ctx, q, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query mock interviews: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.MockInterviewListItem{}
+	for rows.Next() {
+		var item model.MockInterviewListItem
+		if err := rows.Scan(&item.MockID, &item.CompanyID, &item.CompanyName, &item.Title, &item.Status, &item.CreatedAt,
+			&item.TotalQuestions, &item.Answered, &item.AvgScore,
+		); err != nil {
+			return nil, 0, fmt.Errorf("scan mock interview: %w", err)
+		}
+		out = append(out, item)
+	}
+	return out, total, nil
+}
+
+// SaveMockAnswer stores the answer and feedback for a mock question and marks the
+// mock interview completed once every question has an answer
+func (r *Repository) SaveMockAnswer(ctx context.Context, mockID, mqID int64, answer string, feedback *model.MockFeedback) (*model.MockInterviewQuestion, error) {
+	var mq model.MockInterviewQuestion
+	err := r.execTx(ctx, func(tx pgx.Tx) error {
+		const qAnswer = `
+UPDATE mock_interview_questions
+SET answer = $3, feedback = $4, score = $5, answered_at = NOW()
+WHERE mock_id = $1 AND mq_id = $2
+RETURNING mq_id, round_no, round_name, position, question, type, q_id, times_asked, answer, feedback, score, answered_at`
+		var score *int
+		if feedback != nil {
+			score = &feedback.Score
+		}
+		err := tx.QueryRow(ctx, qAnswer, mockID, mqID, answer, feedback, score).Scan(
+			&mq.MQID, &mq.RoundNo, &mq.RoundName, &mq.Position, &mq.Question, &mq.Type,
+			&mq.QID, &mq.TimesAsked, &mq.Answer, &mq.Feedback, &mq.Score, &mq.AnsweredAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		const qComplete = `
+UPDATE mock_interviews SET status = 'completed'
+WHERE mock_id = $1 AND status != 'completed'
+	AND NOT EXISTS (SELECT 1 FROM mock_interview_questions WHERE mock_id = $1 AND answered_at IS NULL)`
+		if _, err := tx.Exec(ctx, qComplete, mockID); err != nil {
+			return fmt.Errorf("complete mock interview: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mq, nil
+}
+
+func (r *Repository) DeleteMockInterview(ctx context.Context, userID uuid.UUID, mockID int64) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM mock_interviews WHERE user_id = $1 AND mock_id = $2`, userID, mockID)
+	if err != nil {
+		return false, fmt.Errorf("delete mock interview: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
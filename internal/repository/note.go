@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrRevisionConflict is returned when a note was saved from an outdated revision
+var ErrRevisionConflict = errors.New("note revision conflict")
+
+func (r *Repository) GetQuestionNote(ctx context.Context, qID int64) (*model.QuestionNote, error) {
+	const q = `SELECT q_id, content, snippets, revision, updated_at FROM question_notes WHERE q_id = $1`
+	var n model.QuestionNote
+	if err := r.db.QueryRow(ctx, q, qID).Scan(&n.QID, &n.Content, &n.Snippets, &n.Revision, &n.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// SaveQuestionNote writes a new revision of a question's note. When baseRevision is set
+// and does not match the stored revision ErrRevisionConflict is returned.
+func (r *Repository) SaveQuestionNote(ctx context.Context, userID uuid.UUID, qID int64, content string, snippets []model.CodeSnippet, baseRevision *int) (*model.QuestionNote, error) {
+	if snippets == nil {
+		snippets = []model.CodeSnippet{}
+	}
+
+	note := &model.QuestionNote{QID: qID, Content: content, Snippets: snippets}
+	err := r.execTx(ctx, func(tx pgx.Tx) error {
+		var current int
+		err := tx.QueryRow(ctx, `SELECT revision FROM question_notes WHERE q_id = $1 FOR UPDATE`, qID).Scan(&current)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("lock question note: %w", err)
+		}
+		if baseRevision != nil && *baseRevision != current {
+			return ErrRevisionConflict
+		}
+
+		const qUpsert = `
+INSERT INTO question_notes (q_id, user_id, content, snippets, revision)
+VALUES ($1, $2, $3, $4, 1)
+ON CONFLICT (q_id) DO UPDATE SET
+	content = EXCLUDED.content,
+	snippets = EXCLUDED.snippets,
+	revision = question_notes.revision + 1
+RETURNING revision, updated_at`
+		if err := tx.QueryRow(ctx, qUpsert, qID, userID, content, snippets).Scan(&note.Revision, &note.UpdatedAt); err != nil {
+			return fmt.Errorf("upsert question note: %w", err)
+		}
+
+		const qRevision = `INSERT INTO question_note_revisions (q_id, revision, content, snippets) VALUES ($1, $2, $3, $4)`
+		if _, err := tx.Exec(ctx, qRevision, qID, note.Revision, content, snippets); err != nil {
+			return fmt.Errorf("insert note revision: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+func (r *Repository) ListNoteRevisions(ctx context.Context, qID int64) ([]model.NoteRevision, error) {
+	const q = `SELECT revision, content, snippets, created_at FROM question_note_revisions WHERE q_id = $1 ORDER BY revision DESC`
+	rows, err := r.db.Query(ctx, q, qID)
+	if err != nil {
+		return nil, fmt.Errorf("query note revisions: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.NoteRevision{}
+	for rows.Next() {
+		var rev model.NoteRevision
+		if err := rows.Scan(&rev.Revision, &rev.Content, &rev.Snippets, &rev.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan note revision: %w", err)
+		}
+		out = append(out, rev)
+	}
+	return out, nil
+}
+
+// SearchQuestions runs a full-text search over question text and the user's notes. Like
+// GlobalSearch the query is parsed in the user's language and unstemmed, questions and
+// notes are indexed in the language of their interview.
+func (r *Repository) SearchQuestions(ctx context.Context, userID uuid.UUID, search string, qType *string, limit, offset int) ([]model.QuestionSearchResult, int, error) {
+	whereConditions := `i.user_id = $1 AND (
+	to_tsvector(search_config_for(i.language), q.question) @@ query.tsq
+	OR n.search_tsv @@ query.tsq)`
+	args := []interface{}{userID, search}
+	if qType != nil && *qType != "" {
+		args = append(args, *qType)
+		whereConditions += fmt.Sprintf(" AND q.type = $%d", len(args))
+	}
+
+	const from = `
+FROM questions q
+INNER JOIN interviews i ON i.interview_id = q.interview_id
+INNER JOIN companies c ON c.company_id = i.company_id
+LEFT JOIN question_notes n ON n.q_id = q.q_id
+CROSS JOIN (
+	SELECT plainto_tsquery(search_config_for(search_language), $2) || plainto_tsquery('simple', $2) AS tsq
+	FROM users WHERE user_id = $1
+) query`
+
+	var total int
+	countQ := "SELECT COUNT(*) " + from + " WHERE " + whereConditions
+	if err := r.db.QueryRow(ctx, countQ, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count question search: %w", err)
+	}
+
+	listQ := fmt.Sprintf(`
+SELECT q.q_id, q.interview_id, q.question, q.type, c.name,
+	ts_headline(search_config_for(i.language), COALESCE(NULLIF(n.content, ''), q.question), query.tsq,
+		'MaxFragments=2, MaxWords=20, MinWords=5') AS snippet,
+	(ts_rank(to_tsvector(search_config_for(i.language), q.question), query.tsq) * 2
+		+ COALESCE(ts_rank(n.search_tsv, query.tsq), 0)) AS rank
+%s
+WHERE %s
+ORDER BY rank DESC, q.q_id DESC
+LIMIT $%d OFFSET $%d`, from, whereConditions, len(args)+1, len(args)+2)
+
+	rows, err := r.db.Query(ctx, listQ, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query question search: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]model.QuestionSearchResult, 0, limit)
+	for rows.Next() {
+		var res model.QuestionSearchResult
+		if err := rows.Scan(&res.QID, &res.InterviewID, &res.Question, &res.Type, &res.CompanyName, &res.Snippet, &res.Rank); err != nil {
+			return nil, 0, fmt.Errorf("scan question search: %w", err)
+		}
+		out = append(out, res)
+	}
+	return out, total, nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const progressColumns = `p.user_id, p.q_id, p.status, p.confidence, p.ease_factor, p.interval_days,
+	p.repetitions, p.due_at, p.last_reviewed_at, p.review_count`
+
+func (r *Repository) GetQuestionProgress(ctx context.Context, userID uuid.UUID, qID int64) (*model.QuestionProgress, error) {
+	const q = `SELECT ` + progressColumns + ` FROM question_progress p WHERE p.user_id = $1 AND p.q_id = $2`
+	var p model.QuestionProgress
+	err := r.db.QueryRow(ctx, q, userID, qID).Scan(
+		&p.UserID, &p.QID, &p.Status, &p.Confidence, &p.EaseFactor, &p.IntervalDays,
+		&p.Repetitions, &p.DueAt, &p.LastReviewedAt, &p.ReviewCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// SaveReview stores the scheduling state produced by a review and appends it to the review log
+func (r *Repository) SaveReview(ctx context.Context, p *model.QuestionProgress, quality int) error {
+	return r.execTx(ctx, func(tx pgx.Tx) error {
+		const qProgress = `
+INSERT INTO question_progress (
+	user_id, q_id, status, confidence, ease_factor, interval_days, repetitions, due_at, last_reviewed_at, review_count
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 1)
+ON CONFLICT (user_id, q_id) DO UPDATE SET
+	status = EXCLUDED.status,
+	confidence = COALESCE(EXCLUDED.confidence, question_progress.confidence),
+	ease_factor = EXCLUDED.ease_factor,
+	interval_days = EXCLUDED.interval_days,
+	repetitions = EXCLUDED.repetitions,
+	due_at = EXCLUDED.due_at,
+	last_reviewed_at = EXCLUDED.last_reviewed_at,
+	review_count = question_progress.review_count + 1
+RETURNING review_count`
+		err := tx.QueryRow(ctx, qProgress,
+			p.UserID, p.QID, p.Status, p.Confidence, p.EaseFactor, p.IntervalDays, p.Repetitions, p.DueAt, p.LastReviewedAt,
+		).Scan(&p.ReviewCount)
+		if err != nil {
+			return fmt.Errorf("upsert question progress: %w", err)
+		}
+
+		const qLog = `INSERT INTO question_reviews (user_id, q_id, quality, confidence) VALUES ($1, $2, $3, $4)`
+		if _, err := tx.Exec(ctx, qLog, p.UserID, p.QID, quality, p.Confidence); err != nil {
+			return fmt.Errorf("insert question review: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetProgressStatus sets the status and confidence of a question without touching its schedule
+func (r *Repository) SetProgressStatus(ctx context.Context, userID uuid.UUID, qID int64, status model.ProgressStatus, confidence *int) error {
+	const q = `
+INSERT INTO question_progress (user_id, q_id, status, confidence) VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, q_id) DO UPDATE SET
+	status = EXCLUDED.status,
+	confidence = COALESCE(EXCLUDED.confidence, question_progress.confidence)`
+	if _, err := r.db.Exec(ctx, q, userID, qID, status, confidence); err != nil {
+		return fmt.Errorf("set question progress: %w", err)
+	}
+	return nil
+}
+
+// ListDuePractice returns questions whose next review is due, oldest first
+func (r *Repository) ListDuePractice(ctx context.Context, userID uuid.UUID, limit int, qType *string) ([]model.PracticeItem, error) {
+	q := `
+SELECT q.q_id, q.interview_id, q.question, q.type, c.name, ` + progressColumns + `
+FROM question_progress p
+INNER JOIN questions q ON q.q_id = p.q_id
+INNER JOIN interviews i ON i.interview_id = q.interview_id
+INNER JOIN companies c ON c.company_id = i.company_id
+WHERE p.user_id = $1 AND p.due_at <= NOW()`
+	args := []interface{}{userID}
+	if qType != nil && *qType != "" {
+		args = append(args, *qType)
+		q += fmt.Sprintf(" AND q.type = $%d", len(args))
+	}
+	args = append(args, limit)
+	q += fmt.Sprintf(" ORDER BY p.due_at ASC LIMIT $%d", len(args))
+
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query due practice: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.PracticeItem
+	for rows.Next() {
+		var (
+			item model.PracticeItem
+			p    model.QuestionProgress
+		)
+		if err := rows.Scan(&item.QID, &item.InterviewID, &item.Question, &item.Type, &item.CompanyName,
+			&p.UserID, &p.QID, &p.Status, &p.Confidence, &p.EaseFactor, &p.IntervalDays,
+			&p.Repetitions, &p.DueAt, &p.LastReviewedAt, &p.ReviewCount,
+		); err != nil {
+			return nil, fmt.Errorf("scan due practice: %w", err)
+		}
+		item.Progress = &p
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+// ListNewPractice returns questions that have never been scheduled, newest interviews first
+func (r *Repository) ListNewPractice(ctx context.Context, userID uuid.UUID, limit int, qType *string) ([]model.PracticeItem, error) {
+	q := `
+SELECT q.q_id, q.interview_id, q.question, q.type, c.name
+FROM questions q
+INNER JOIN interviews i ON i.interview_id = q.interview_id
+INNER JOIN companies c ON c.company_id = i.company_id
+LEFT JOIN question_progress p ON p.q_id = q.q_id AND p.user_id = $1
+WHERE i.user_id = $1 AND (p.q_id IS NULL OR (p.due_at IS NULL AND p.status != 'solved'))`
+	args := []interface{}{userID}
+	if qType != nil && *qType != "" {
+		args = append(args, *qType)
+		q += fmt.Sprintf(" AND q.type = $%d", len(args))
+	}
+	args = append(args, limit)
+	q += fmt.Sprintf(" ORDER BY i.created_at DESC, q.q_id ASC LIMIT $%d", len(args))
+
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query new practice: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.PracticeItem
+	for rows.Next() {
+		var item model.PracticeItem
+		if err := rows.Scan(&item.QID, &item.InterviewID, &item.Question, &item.Type, &item.CompanyName); err != nil {
+			return nil, fmt.Errorf("scan new practice: %w", err)
+		}
+		item.IsNew = true
+		out = append(out, item)
+	}
+	return out, nil
+}
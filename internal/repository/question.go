@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
 
@@ -36,7 +37,7 @@ func (r *Repository) CreateQuestions(ctx context.Context, questions []model.Ques
 
 func (r *Repository) ListQuestionByInterviewID(ctx context.Context, interviewID int64) ([]model.QuestionRes, error) {
 	const q = `
-SELECT q_id, interview_id, question, type
+SELECT ` + questionResColumns + `
 FROM questions
 WHERE interview_id = $1
 ORDER BY created_at ASC
@@ -49,22 +50,81 @@ ORDER BY created_at ASC
 
 	var out []model.QuestionRes
 	for rows.Next() {
-		var qs model.QuestionRes
-		if err := rows.Scan(&qs.QID, &qs.InterviewID, &qs.Question, &qs.Type); err != nil {
+		qs, err := scanQuestionRes(rows)
+		if err != nil {
 			return nil, fmt.Errorf("scan question: %w", err)
 		}
-		out = append(out, qs)
+		out = append(out, *qs)
 	}
 	return out, nil
 }
 
-func (r *Repository) UpdateQuestion(ctx context.Context, qID int64, question string, questionType string) error {
-	const q = `UPDATE questions SET question = $1, "type" = $2 WHERE q_id = $3`
-	_, err := r.db.Exec(ctx, q, question, questionType, qID)
-	if err != nil {
-		return fmt.Errorf("update question: %w", err)
+func (r *Repository) GetQuestionByID(ctx context.Context, qID int64) (*model.QuestionRes, error) {
+	const q = `SELECT ` + questionResColumns + ` FROM questions WHERE q_id = $1`
+	return scanQuestionRes(r.db.QueryRow(ctx, q, qID))
+}
+
+const questionResColumns = `q_id, interview_id, question, type, cq_id,
+	lc_slug, lc_title, lc_difficulty, lc_topics, lc_score, lc_status`
+
+// scanQuestionRes scans a row selected with questionResColumns
+func scanQuestionRes(row pgx.Row) (*model.QuestionRes, error) {
+	var (
+		qs         model.QuestionRes
+		slug       *string
+		title      *string
+		difficulty *string
+		topics     []string
+		score      *float32
+		status     *string
+	)
+	if err := row.Scan(&qs.QID, &qs.InterviewID, &qs.Question, &qs.Type, &qs.CQID,
+		&slug, &title, &difficulty, &topics, &score, &status); err != nil {
+		return nil, err
 	}
-	return nil
+
+	if slug != nil && status != nil {
+		qs.Leetcode = &model.LeetcodeLink{
+			Slug:   *slug,
+			URL:    "https://leetcode.com/problems/" + *slug + "/",
+			Topics: topics,
+			Status: model.LeetcodeLinkStatus(*status),
+		}
+		if title != nil {
+			qs.Leetcode.Title = *title
+		}
+		if difficulty != nil {
+			qs.Leetcode.Difficulty = *difficulty
+		}
+		if score != nil {
+			qs.Leetcode.Score = *score
+		}
+		if qs.Leetcode.Topics == nil {
+			qs.Leetcode.Topics = []string{}
+		}
+	}
+	return &qs, nil
+}
+
+// UpdateQuestion rewrites a question and returns the id of its interview
+func (r *Repository) UpdateQuestion(ctx context.Context, qID int64, question string, questionType string) (int64, error) {
+	// the wording may have changed, so drop the canonical link for relinking
+	// LeetCode links are kept only when the user confirmed them
+	const q = `
+UPDATE questions SET question = $1, "type" = $2, cq_id = NULL,
+	lc_slug = CASE WHEN lc_status = 'confirmed' THEN lc_slug END,
+	lc_title = CASE WHEN lc_status = 'confirmed' THEN lc_title END,
+	lc_difficulty = CASE WHEN lc_status = 'confirmed' THEN lc_difficulty END,
+	lc_topics = CASE WHEN lc_status = 'confirmed' THEN lc_topics END,
+	lc_score = CASE WHEN lc_status = 'confirmed' THEN lc_score END,
+	lc_status = CASE WHEN lc_status = 'confirmed' THEN lc_status END
+WHERE q_id = $3
+RETURNING interview_id`
+	var interviewID int64
+	if err := r.db.QueryRow(ctx, q, question, questionType, qID).Scan(&interviewID); err != nil {
+		return 0, fmt.Errorf("update question: %w", err)
+	}
+	return interviewID, nil
 }
 
 func (r *Repository) DeleteQuestion(ctx context.Context, qID int64) error {
@@ -84,3 +144,17 @@ func (r *Repository) CreateQuestion(ctx context.Context, question *model.Questio
 	}
 	return question, nil
 }
+
+func (r *Repository) QuestionBelongsToUser(ctx context.Context, qID int64, userID uuid.UUID) (bool, error) {
+	const q = `
+SELECT EXISTS (
+	SELECT 1 FROM questions q
+	INNER JOIN interviews i ON i.interview_id = q.interview_id
+	WHERE q.q_id = $1 AND i.user_id = $2
+)`
+	var exists bool
+	if err := r.db.QueryRow(ctx, q, qID, userID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("check question owner: %w", err)
+	}
+	return exists, nil
+}
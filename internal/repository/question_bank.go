@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+)
+
+func (r *Repository) ListCanonicalQuestions(ctx context.Context, userID uuid.UUID) ([]model.CanonicalQuestion, error) {
+	const q = `SELECT cq_id, user_id, question, normalized, type, created_at FROM canonical_questions WHERE user_id = $1`
+	rows, err := r.db.Query(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query canonical questions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.CanonicalQuestion
+	for rows.Next() {
+		var cq model.CanonicalQuestion
+		if err := rows.Scan(&cq.CQID, &cq.UserID, &cq.Question, &cq.Normalized, &cq.Type, &cq.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan canonical question: %w", err)
+		}
+		out = append(out, cq)
+	}
+	return out, nil
+}
+
+// CreateCanonicalQuestion inserts a canonical question, returning the existing row
+// when another question already claimed the same normalized text
+func (r *Repository) CreateCanonicalQuestion(ctx context.Context, cq *model.CanonicalQuestion) (*model.CanonicalQuestion, error) {
+	const q = `
+INSERT INTO canonical_questions (user_id, question, normalized, type)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, normalized) DO UPDATE SET normalized = EXCLUDED.normalized
+RETURNING cq_id, created_at`
+	err := r.db.QueryRow(ctx, q, cq.UserID, cq.Question, cq.Normalized, cq.Type).Scan(&cq.CQID, &cq.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("insert canonical question: %w", err)
+	}
+	return cq, nil
+}
+
+// ListUnlinkedQuestions returns the user's questions that are not yet linked to a
+// canonical question, optionally limited to a single interview
+func (r *Repository) ListUnlinkedQuestions(ctx context.Context, userID uuid.UUID, interviewID *int64) ([]model.QuestionRes, error) {
+	q := `
+SELECT q.q_id, q.interview_id, q.question, q.type, q.cq_id
+FROM questions q
+INNER JOIN interviews i ON i.interview_id = q.interview_id
+WHERE i.user_id = $1 AND q.cq_id IS NULL`
+	args := []interface{}{userID}
+	if interviewID != nil {
+		q += " AND q.interview_id = $2"
+		args = append(args, *interviewID)
+	}
+	q += " ORDER BY q.q_id ASC"
+
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query unlinked questions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.QuestionRes
+	for rows.Next() {
+		var qs model.QuestionRes
+		if err := rows.Scan(&qs.QID, &qs.InterviewID, &qs.Question, &qs.Type, &qs.CQID); err != nil {
+			return nil, fmt.Errorf("scan unlinked question: %w", err)
+		}
+		out = append(out, qs)
+	}
+	return out, nil
+}
+
+func (r *Repository) SetQuestionCanonical(ctx context.Context, qID, cqID int64) error {
+	const q = `UPDATE questions SET cq_id = $1 WHERE q_id = $2`
+	if _, err := r.db.Exec(ctx, q, cqID, qID); err != nil {
+		return fmt.Errorf("link canonical question: %w", err)
+	}
+	return nil
+}
+
+// QuestionBank lists canonical questions with how often, where and when they were asked
+func (r *Repository) QuestionBank(ctx context.Context, userID uuid.UUID, qType *string, companyID *uuid.UUID, limit, offset int) ([]model.QuestionBankItem, int, error) {
+	whereConditions := []string{"cq.user_id = $1"}
+	args := []interface{}{userID}
+
+	if qType != nil && *qType != "" {
+		args = append(args, *qType)
+		whereConditions = append(whereConditions, fmt.Sprintf("cq.type = $%d", len(args)))
+	}
+	if companyID != nil {
+		args = append(args, *companyID)
+		whereConditions = append(whereConditions, fmt.Sprintf(`EXISTS (
+	SELECT 1 FROM questions fq INNER JOIN interviews fi ON fi.interview_id = fq.interview_id
+	WHERE fq.cq_id = cq.cq_id AND fi.company_id = $%d)`, len(args)))
+	}
+	whereClause := strings.Join(whereConditions, " AND ")
+
+	var total int
+	countQ := fmt.Sprintf(`SELECT COUNT(*) FROM canonical_questions cq WHERE %s
+	AND EXISTS (SELECT 1 FROM questions q WHERE q.cq_id = cq.cq_id)`, whereClause)
+	if err := r.db.QueryRow(ctx, countQ, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count question bank: %w", err)
+	}
+
+	listQ := fmt.Sprintf(`
+SELECT cq.cq_id, cq.question, cq.type,
+	COUNT(q.q_id) AS frequency,
+	COALESCE(array_agg(DISTINCT c.name) FILTER (WHERE c.name != 'unknown company'), '{}') AS companies,
+	MAX(i.created_at) AS last_asked_at
+FROM canonical_questions cq
+INNER JOIN questions q ON q.cq_id = cq.cq_id
+INNER JOIN interviews i ON i.interview_id = q.interview_id
+INNER JOIN companies c ON c.company_id = i.company_id
+WHERE %s
+GROUP BY cq.cq_id
+ORDER BY frequency DESC, last_asked_at DESC
+LIMIT $%d OFFSET $%d`, whereClause, len(args)+1, len(args)+2)
+
+	rows, err := r.db.Query(ctx, listQ, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query question bank: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]model.QuestionBankItem, 0, limit)
+	for rows.Next() {
+		var item model.QuestionBankItem
+		if err := rows.Scan(&item.CQID, &item.Question, &item.Type, &item.Frequency, &item.Companies, &item.LastAskedAt); err != nil {
+			return nil, 0, fmt.Errorf("scan question bank row: %w", err)
+		}
+		out = append(out, item)
+	}
+	if rows.Err() != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", rows.Err())
+	}
+	return out, total, nil
+}
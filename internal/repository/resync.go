@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/jackc/pgx/v5"
+)
+
+// ListResyncQuestions returns the questions of an interview that re-extracted ones are matched against
+func (r *Repository) ListResyncQuestions(ctx context.Context, interviewID int64) ([]model.ResyncQuestion, error) {
+	const q = `SELECT q_id, question, type FROM questions WHERE interview_id = $1 ORDER BY q_id`
+	rows, err := r.db.Query(ctx, q, interviewID)
+	if err != nil {
+		return nil, fmt.Errorf("query resync questions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.ResyncQuestion
+	for rows.Next() {
+		var rq model.ResyncQuestion
+		if err := rows.Scan(&rq.QID, &rq.Question, &rq.Type); err != nil {
+			return nil, fmt.Errorf("scan resync question: %w", err)
+		}
+		out = append(out, rq)
+	}
+	return out, rows.Err()
+}
+
+// AddResyncQuestions adds the new questions of a resynced interview in one transaction
+func (r *Repository) AddResyncQuestions(ctx context.Context, interviewID int64, add []model.Question) error {
+	return r.execTx(ctx, func(tx pgx.Tx) error {
+		const qInsert = `INSERT INTO questions (interview_id, question, "type") VALUES ($1, $2, $3)`
+		for _, question := range add {
+			if _, err := tx.Exec(ctx, qInsert, interviewID, question.Question, question.Type); err != nil {
+				return fmt.Errorf("insert resynced question: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ListResyncCandidates returns interviews imported from a URL after createdAfter whose
+// source was last fetched before fetchedBefore, oldest fetch first
+func (r *Repository) ListResyncCandidates(ctx context.Context, createdAfter, fetchedBefore time.Time, limit int) ([]model.ResyncCandidate, error) {
+	const q = `
+SELECT interview_id, user_id FROM interviews
+WHERE source_url IS NOT NULL AND process_status <> 'queued' AND created_at > $1 AND (fetched_at IS NULL OR fetched_at < $2)
+ORDER BY fetched_at ASC NULLS FIRST
+LIMIT $3`
+	rows, err := r.db.Query(ctx, q, createdAfter, fetchedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query resync candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.ResyncCandidate
+	for rows.Next() {
+		var c model.ResyncCandidate
+		if err := rows.Scan(&c.InterviewID, &c.UserID); err != nil {
+			return nil, fmt.Errorf("scan resync candidate: %w", err)
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrScrapeRuleExists is returned when another rule has the same name
+var ErrScrapeRuleExists = errors.New("scrape rule name already used")
+
+const scrapeRuleColumns = `rule_id, name, host_pattern, path_pattern, title_selector, content_selector,
+date_selector, remove_selectors, enabled, created_at`
+
+func scrapeRuleError(err error, action string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrScrapeRuleExists
+	}
+	return fmt.Errorf("%s scrape rule: %w", action, err)
+}
+
+func (r *Repository) CreateScrapeRule(ctx context.Context, rule *model.ScrapeRule) error {
+	const q = `INSERT INTO scrape_rules (name, host_pattern, path_pattern, title_selector, content_selector,
+	date_selector, remove_selectors, enabled)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING rule_id, created_at`
+	err := r.db.QueryRow(ctx, q, rule.Name, rule.HostPattern, rule.PathPattern, rule.TitleSelector, rule.ContentSelector,
+		rule.DateSelector, rule.RemoveSelectors, rule.Enabled).Scan(&rule.RuleID, &rule.CreatedAt)
+	if err != nil {
+		return scrapeRuleError(err, "insert")
+	}
+	return nil
+}
+
+// UpdateScrapeRule replaces a rule, it reports false when the rule does not exist
+func (r *Repository) UpdateScrapeRule(ctx context.Context, rule *model.ScrapeRule) (bool, error) {
+	const q = `UPDATE scrape_rules SET name = $2, host_pattern = $3, path_pattern = $4, title_selector = $5,
+	content_selector = $6, date_selector = $7, remove_selectors = $8, enabled = $9
+WHERE rule_id = $1
+RETURNING created_at`
+	err := r.db.QueryRow(ctx, q, rule.RuleID, rule.Name, rule.HostPattern, rule.PathPattern, rule.TitleSelector,
+		rule.ContentSelector, rule.DateSelector, rule.RemoveSelectors, rule.Enabled).Scan(&rule.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, scrapeRuleError(err, "update")
+	}
+	return true, nil
+}
+
+func (r *Repository) DeleteScrapeRule(ctx context.Context, ruleID int64) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM scrape_rules WHERE rule_id = $1`, ruleID)
+	if err != nil {
+		return false, fmt.Errorf("delete scrape rule: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetScrapeRule returns pgx.ErrNoRows when the rule does not exist
+func (r *Repository) GetScrapeRule(ctx context.Context, ruleID int64) (*model.ScrapeRule, error) {
+	rules, err := r.queryScrapeRules(ctx, `SELECT `+scrapeRuleColumns+` FROM scrape_rules WHERE rule_id = $1`, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, pgx.ErrNoRows
+	}
+	return &rules[0], nil
+}
+
+// ListScrapeRules returns the rules in the order they are tried
+func (r *Repository) ListScrapeRules(ctx context.Context, enabledOnly bool) ([]model.ScrapeRule, error) {
+	q := `SELECT ` + scrapeRuleColumns + ` FROM scrape_rules`
+	if enabledOnly {
+		q += ` WHERE enabled`
+	}
+	return r.queryScrapeRules(ctx, q+` ORDER BY rule_id`)
+}
+
+func (r *Repository) queryScrapeRules(ctx context.Context, q string, args ...interface{}) ([]model.ScrapeRule, error) {
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query scrape rules: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.ScrapeRule{}
+	for rows.Next() {
+		var rule model.ScrapeRule
+		if err := rows.Scan(&rule.RuleID, &rule.Name, &rule.HostPattern, &rule.PathPattern, &rule.TitleSelector,
+			&rule.ContentSelector, &rule.DateSelector, &rule.RemoveSelectors, &rule.Enabled, &rule.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan scrape rule: %w", err)
+		}
+		out = append(out, rule)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+)
+
+// searchHits matches interviews, questions and companies of user $1 against query $2.
+// The query is parsed in the user's preferred language and also unstemmed, so interviews
+// indexed in another language still match. Questions are indexed in the language of their
+// interview and companies in the user's language. doc is the text the snippet is cut
+// from, in the configuration cfg.
+const searchHits = `
+WITH query AS (
+	SELECT websearch_to_tsquery(search_config_for(u.search_language), $2)
+		|| websearch_to_tsquery('simple', $2) AS tsq,
+		search_config_for(u.search_language) AS cfg
+	FROM users u WHERE u.user_id = $1
+),
+hits AS (
+	SELECT 'interview' AS type, i.interview_id, NULL::bigint AS q_id, c.company_id, c.name AS company_name,
+		COALESCE(NULLIF(i.metadata->>'title', ''), NULLIF(i.position, ''), c.name) AS title,
+		COALESCE(NULLIF(i.metadata->>'full_experience', ''), i.raw_input) AS doc, search_config_for(i.language) AS cfg,
+		ts_rank(i.search_tsv, query.tsq) AS rank, i.created_at
+	FROM interviews i
+	INNER JOIN companies c ON c.company_id = i.company_id, query
+	WHERE i.user_id = $1 AND i.search_tsv @@ query.tsq
+	UNION ALL
+	SELECT 'question', q.interview_id, q.q_id, c.company_id, c.name,
+		q.question, q.question, search_config_for(i.language),
+		ts_rank(setweight(to_tsvector(search_config_for(i.language), q.question), 'B'), query.tsq), q.created_at
+	FROM questions q
+	INNER JOIN interviews i ON i.interview_id = q.interview_id
+	INNER JOIN companies c ON c.company_id = i.company_id, query
+	WHERE i.user_id = $1 AND to_tsvector(search_config_for(i.language), q.question) @@ query.tsq
+	UNION ALL
+	SELECT 'company', NULL, NULL, c.company_id, c.name,
+		c.name, c.name, query.cfg,
+		ts_rank(setweight(to_tsvector(query.cfg, c.name), 'A'), query.tsq), c.created_at
+	FROM companies c, query
+	WHERE c.user_id = $1 AND to_tsvector(query.cfg, c.name) @@ query.tsq
+)`
+
+// GlobalSearch runs a full-text search across every interview, question and company of the user.
+// Facets are counted before the type filter is applied.
+func (r *Repository) GlobalSearch(ctx context.Context, userID uuid.UUID, search string, types []model.SearchResultType, limit, offset int) ([]model.SearchResult, map[model.SearchResultType]int, error) {
+	facets := map[model.SearchResultType]int{
+		model.SearchResultInterview: 0,
+		model.SearchResultQuestion:  0,
+		model.SearchResultCompany:   0,
+	}
+
+	rows, err := r.db.Query(ctx, searchHits+` SELECT type, COUNT(*) FROM hits GROUP BY type`, userID, search)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query search facets: %w", err)
+	}
+	for rows.Next() {
+		var (
+			t     model.SearchResultType
+			count int
+		)
+		if err := rows.Scan(&t, &count); err != nil {
+			rows.Close()
+			return nil, nil, fmt.Errorf("scan search facet: %w", err)
+		}
+		facets[t] = count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("search facets rows: %w", err)
+	}
+
+	var typeFilter []string
+	for _, t := range types {
+		typeFilter = append(typeFilter, string(t))
+	}
+
+	const listQ = searchHits + `
+SELECT h.type, h.interview_id, h.q_id, h.company_id, h.company_name, h.title,
+	ts_headline(h.cfg, h.doc, query.tsq, 'MaxFragments=2, MaxWords=20, MinWords=5') AS snippet,
+	h.rank, h.created_at
+FROM hits h, query
+WHERE $3::text[] IS NULL OR h.type = ANY($3)
+ORDER BY h.rank DESC, h.created_at DESC
+LIMIT $4 OFFSET $5`
+	rows, err = r.db.Query(ctx, listQ, userID, search, typeFilter, limit, offset)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query search: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]model.SearchResult, 0, limit)
+	for rows.Next() {
+		var res model.SearchResult
+		if err := rows.Scan(&res.Type, &res.InterviewID, &res.QID, &res.CompanyID, &res.CompanyName, &res.Title,
+			&res.Snippet, &res.Rank, &res.CreatedAt,
+		); err != nil {
+			return nil, nil, fmt.Errorf("scan search result: %w", err)
+		}
+		out = append(out, res)
+	}
+	return out, facets, rows.Err()
+}
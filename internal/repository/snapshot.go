@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+)
+
+// CreateInterviewSnapshot records the snapshot of an interview, the first one is kept
+func (r *Repository) CreateInterviewSnapshot(ctx context.Context, s *model.InterviewSnapshot) error {
+	const q = `INSERT INTO interview_snapshots (interview_id, blob_key, content_type, size, source_url, fetched_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (interview_id) DO NOTHING`
+	if _, err := r.db.Exec(ctx, q, s.InterviewID, s.BlobKey, s.ContentType, s.Size, s.SourceURL, s.FetchedAt); err != nil {
+		return fmt.Errorf("insert interview snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetInterviewSnapshot returns pgx.ErrNoRows when the interview has no snapshot or is not the user's
+func (r *Repository) GetInterviewSnapshot(ctx context.Context, userID uuid.UUID, interviewID int64) (*model.InterviewSnapshot, error) {
+	const q = `SELECT s.interview_id, s.blob_key, s.content_type, s.size, s.source_url, s.fetched_at
+FROM interview_snapshots s
+JOIN interviews i ON i.interview_id = s.interview_id
+WHERE s.interview_id = $1 AND i.user_id = $2`
+
+	var s model.InterviewSnapshot
+	if err := r.db.QueryRow(ctx, q, interviewID, userID).Scan(&s.InterviewID, &s.BlobKey, &s.ContentType, &s.Size, &s.SourceURL, &s.FetchedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrTagExists is returned when a tag with the same slug already exists for the user
+var ErrTagExists = errors.New("tag already exists")
+
+func (r *Repository) CreateTag(ctx context.Context, tag *model.Tag) (*model.Tag, error) {
+	const q = `INSERT INTO tags (user_id, name, slug) VALUES ($1, $2, $3) RETURNING tag_id, created_at, updated_at`
+	err := r.db.QueryRow(ctx, q, tag.UserID, tag.Name, tag.Slug).Scan(&tag.TagID, &tag.CreatedAt, &tag.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrTagExists
+		}
+		return nil, fmt.Errorf("insert tag: %w", err)
+	}
+	return tag, nil
+}
+
+func (r *Repository) ListTags(ctx context.Context, userID uuid.UUID) ([]model.TagRes, error) {
+	const q = `
+SELECT t.tag_id, t.name, t.slug,
+	(SELECT COUNT(*) FROM interview_tags it WHERE it.tag_id = t.tag_id) AS interview_count,
+	(SELECT COUNT(*) FROM question_tags qt WHERE qt.tag_id = t.tag_id) AS question_count
+FROM tags t
+WHERE t.user_id = $1
+ORDER BY t.name ASC
+`
+	rows, err := r.db.Query(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query tags: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.TagRes
+	for rows.Next() {
+		var t model.TagRes
+		if err := rows.Scan(&t.TagID, &t.Name, &t.Slug, &t.InterviewCount, &t.QuestionCount); err != nil {
+			return nil, fmt.Errorf("scan tag: %w", err)
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// ListTagNames returns the user's tag vocabulary, used to guide AI tag suggestions
+func (r *Repository) ListTagNames(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	const q = `SELECT name FROM tags WHERE user_id = $1 ORDER BY name ASC`
+	rows, err := r.db.Query(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query tag names: %w", err)
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("scan tag name: %w", err)
+		}
+		out = append(out, name)
+	}
+	return out, nil
+}
+
+func (r *Repository) GetTagByID(ctx context.Context, userID uuid.UUID, tagID int64) (*model.Tag, error) {
+	const q = `SELECT tag_id, user_id, name, slug, created_at, updated_at FROM tags WHERE user_id = $1 AND tag_id = $2`
+	var t model.Tag
+	err := r.db.QueryRow(ctx, q, userID, tagID).Scan(&t.TagID, &t.UserID, &t.Name, &t.Slug, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *Repository) RenameTag(ctx context.Context, userID uuid.UUID, tagID int64, name, slug string) error {
+	const q = `UPDATE tags SET name = $1, slug = $2 WHERE user_id = $3 AND tag_id = $4`
+	tag, err := r.db.Exec(ctx, q, name, slug, userID, tagID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrTagExists
+		}
+		return fmt.Errorf("rename tag: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *Repository) DeleteTag(ctx context.Context, userID uuid.UUID, tagID int64) error {
+	const q = `DELETE FROM tags WHERE user_id = $1 AND tag_id = $2`
+	tag, err := r.db.Exec(ctx, q, userID, tagID)
+	if err != nil {
+		return fmt.Errorf("delete tag: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// MergeTags moves every interview and question link from the source tags onto the
+// target tag and removes the source tags
+func (r *Repository) MergeTags(ctx context.Context, userID uuid.UUID, sourceIDs []int64, targetID int64) error {
+	return r.execTx(ctx, func(tx pgx.Tx) error {
+		var count int
+		const qCheck = `SELECT COUNT(*) FROM tags WHERE user_id = $1 AND (tag_id = ANY($2) OR tag_id = $3)`
+		if err := tx.QueryRow(ctx, qCheck, userID, sourceIDs, targetID).Scan(&count); err != nil {
+			return fmt.Errorf("check merge tags: %w", err)
+		}
+		if count != len(sourceIDs)+1 {
+			return pgx.ErrNoRows
+		}
+
+		const qInterviews = `
+INSERT INTO interview_tags (interview_id, tag_id)
+SELECT interview_id, $1 FROM interview_tags WHERE tag_id = ANY($2)
+ON CONFLICT DO NOTHING`
+		if _, err := tx.Exec(ctx, qInterviews, targetID, sourceIDs); err != nil {
+			return fmt.Errorf("merge interview tags: %w", err)
+		}
+
+		const qQuestions = `
+INSERT INTO question_tags (q_id, tag_id)
+SELECT q_id, $1 FROM question_tags WHERE tag_id = ANY($2)
+ON CONFLICT DO NOTHING`
+		if _, err := tx.Exec(ctx, qQuestions, targetID, sourceIDs); err != nil {
+			return fmt.Errorf("merge question tags: %w", err)
+		}
+
+		const qDelete = `DELETE FROM tags WHERE user_id = $1 AND tag_id = ANY($2)`
+		if _, err := tx.Exec(ctx, qDelete, userID, sourceIDs); err != nil {
+			return fmt.Errorf("delete merged tags: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetInterviewTags replaces the tags attached to an interview
+func (r *Repository) SetInterviewTags(ctx context.Context, userID uuid.UUID, interviewID int64, tagIDs []int64) error {
+	return r.execTx(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM interview_tags WHERE interview_id = $1`, interviewID); err != nil {
+			return fmt.Errorf("clear interview tags: %w", err)
+		}
+		const q = `
+INSERT INTO interview_tags (interview_id, tag_id)
+SELECT $1, tag_id FROM tags WHERE user_id = $2 AND tag_id = ANY($3)`
+		if _, err := tx.Exec(ctx, q, interviewID, userID, tagIDs); err != nil {
+			return fmt.Errorf("insert interview tags: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetQuestionTags replaces the tags attached to a question
+func (r *Repository) SetQuestionTags(ctx context.Context, userID uuid.UUID, qID int64, tagIDs []int64) error {
+	return r.execTx(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM question_tags WHERE q_id = $1`, qID); err != nil {
+			return fmt.Errorf("clear question tags: %w", err)
+		}
+		const q = `
+INSERT INTO question_tags (q_id, tag_id)
+SELECT $1, tag_id FROM tags WHERE user_id = $2 AND tag_id = ANY($3)`
+		if _, err := tx.Exec(ctx, q, qID, userID, tagIDs); err != nil {
+			return fmt.Errorf("insert question tags: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *Repository) ListInterviewTags(ctx context.Context, interviewID int64) ([]model.Tag, error) {
+	const q = `
+SELECT t.tag_id, t.user_id, t.name, t.slug, t.created_at, t.updated_at
+FROM tags t
+INNER JOIN interview_tags it ON it.tag_id = t.tag_id
+WHERE it.interview_id = $1
+ORDER BY t.name ASC
+`
+	rows, err := r.db.Query(ctx, q, interviewID)
+	if err != nil {
+		return nil, fmt.Errorf("query interview tags: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.Tag{}
+	for rows.Next() {
+		var t model.Tag
+		if err := rows.Scan(&t.TagID, &t.UserID, &t.Name, &t.Slug, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan interview tag: %w", err)
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
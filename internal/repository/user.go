@@ -113,3 +113,20 @@ func (r *Repository) UpdateUserPassword(ctx context.Context, userID uuid.UUID, n
 	}
 	return nil
 }
+
+func (r *Repository) GetUserPreferences(ctx context.Context, userID uuid.UUID) (*model.UserPreferences, error) {
+	const q = `SELECT search_language FROM users WHERE user_id = $1`
+	var p model.UserPreferences
+	if err := r.db.QueryRow(ctx, q, userID).Scan(&p.SearchLanguage); err != nil {
+		return nil, fmt.Errorf("query user preferences: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *Repository) UpdateUserPreferences(ctx context.Context, userID uuid.UUID, p *model.UserPreferences) error {
+	const q = `UPDATE users SET search_language = $2 WHERE user_id = $1`
+	if _, err := r.db.Exec(ctx, q, userID, p.SearchLanguage); err != nil {
+		return fmt.Errorf("update user preferences: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/abhishek622/interviewMin/pkg/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrWatchExists is returned when the user already watches the company and position
+var ErrWatchExists = errors.New("company already watched")
+
+const companyWatchColumns = `watch_id, user_id, company, position_keyword, leetcode_tag, sources, digest,
+last_polled_at, last_digest_at, created_at`
+
+func (r *Repository) CreateCompanyWatch(ctx context.Context, w *model.CompanyWatch) error {
+	const q = `INSERT INTO company_watches (user_id, company, position_keyword, leetcode_tag, sources, digest)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING watch_id, created_at`
+	if err := r.db.QueryRow(ctx, q, w.UserID, w.Company, w.PositionKeyword, w.LeetcodeTag, w.Sources, w.Digest).Scan(&w.WatchID, &w.CreatedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrWatchExists
+		}
+		return fmt.Errorf("insert company watch: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) ListCompanyWatches(ctx context.Context, userID uuid.UUID) ([]model.CompanyWatch, error) {
+	q := `SELECT ` + companyWatchColumns + ` FROM company_watches WHERE user_id = $1 ORDER BY company`
+	return r.queryCompanyWatches(ctx, q, userID)
+}
+
+// ListDueWatches returns watches of all users not polled since before, least recently polled first
+func (r *Repository) ListDueWatches(ctx context.Context, before time.Time, limit int) ([]model.CompanyWatch, error) {
+	q := `SELECT ` + companyWatchColumns + ` FROM company_watches
+WHERE last_polled_at IS NULL OR last_polled_at < $1
+ORDER BY last_polled_at ASC NULLS FIRST
+LIMIT $2`
+	return r.queryCompanyWatches(ctx, q, before, limit)
+}
+
+func (r *Repository) queryCompanyWatches(ctx context.Context, q string, args ...interface{}) ([]model.CompanyWatch, error) {
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query company watches: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.CompanyWatch{}
+	for rows.Next() {
+		var w model.CompanyWatch
+		if err := rows.Scan(&w.WatchID, &w.UserID, &w.Company, &w.PositionKeyword, &w.LeetcodeTag, &w.Sources, &w.Digest,
+			&w.LastPolledAt, &w.LastDigestAt, &w.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan company watch: %w", err)
+		}
+		out = append(out, w)
+	}
+	return out, rows.Err()
+}
+
+func (r *Repository) MarkWatchPolled(ctx context.Context, watchID int64, polledAt time.Time) error {
+	if _, err := r.db.Exec(ctx, `UPDATE company_watches SET last_polled_at = $1 WHERE watch_id = $2`, polledAt, watchID); err != nil {
+		return fmt.Errorf("mark watch polled: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) DeleteCompanyWatch(ctx context.Context, userID uuid.UUID, watchID int64) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM company_watches WHERE watch_id = $1 AND user_id = $2`, watchID, userID)
+	if err != nil {
+		return false, fmt.Errorf("delete company watch: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// CreateWatchMatches stores the posts found by a watch and returns the ones it had not found before
+func (r *Repository) CreateWatchMatches(ctx context.Context, w model.CompanyWatch, matches []model.WatchMatch) ([]model.WatchMatch, error) {
+	const q = `INSERT INTO watch_matches (watch_id, user_id, source, url, canonical_url, title, posted_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (watch_id, canonical_url) DO NOTHING
+RETURNING match_id, created_at`
+
+	batch := &pgx.Batch{}
+	for _, m := range matches {
+		batch.Queue(q, w.WatchID, w.UserID, m.Source, m.URL, m.CanonicalURL, m.Title, m.PostedAt)
+	}
+	br := r.db.SendBatch(ctx, batch)
+	defer br.Close()
+
+	var created []model.WatchMatch
+	for _, m := range matches {
+		if err := br.QueryRow().Scan(&m.MatchID, &m.CreatedAt); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return nil, fmt.Errorf("insert watch match: %w", err)
+		}
+		m.WatchID = w.WatchID
+		m.UserID = w.UserID
+		m.Company = w.Company
+		created = append(created, m)
+	}
+	return created, nil
+}
+
+func (r *Repository) SetWatchMatchesBatch(ctx context.Context, matchIDs []int64, batchID int64) error {
+	if _, err := r.db.Exec(ctx, `UPDATE watch_matches SET batch_id = $1 WHERE match_id = ANY($2)`, batchID, matchIDs); err != nil {
+		return fmt.Errorf("set watch matches batch: %w", err)
+	}
+	return nil
+}
+
+// ListDigestMatches returns the matches not reported yet of watches whose digest is due,
+// daily digests are due when last sent before dailyBefore and weekly ones before weeklyBefore
+func (r *Repository) ListDigestMatches(ctx context.Context, dailyBefore, weeklyBefore time.Time) ([]model.WatchMatch, error) {
+	const q = `SELECT m.match_id, m.watch_id, m.user_id, w.digest, w.company, m.source, m.url, m.canonical_url,
+	m.title, m.posted_at, m.batch_id, i.interview_id, m.created_at
+FROM watch_matches m
+JOIN company_watches w ON w.watch_id = m.watch_id
+LEFT JOIN interviews i ON i.user_id = m.user_id AND i.canonical_url = m.canonical_url
+WHERE m.notified_at IS NULL
+  AND ((w.digest = 'daily' AND (w.last_digest_at IS NULL OR w.last_digest_at < $1))
+    OR (w.digest = 'weekly' AND (w.last_digest_at IS NULL OR w.last_digest_at < $2)))
+ORDER BY m.user_id, w.company, m.posted_at DESC NULLS LAST, m.match_id`
+
+	rows, err := r.db.Query(ctx, q, dailyBefore, weeklyBefore)
+	if err != nil {
+		return nil, fmt.Errorf("query digest matches: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.WatchMatch
+	for rows.Next() {
+		var m model.WatchMatch
+		if err := rows.Scan(&m.MatchID, &m.WatchID, &m.UserID, &m.Digest, &m.Company, &m.Source, &m.URL, &m.CanonicalURL,
+			&m.Title, &m.PostedAt, &m.BatchID, &m.InterviewID, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan digest match: %w", err)
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// MarkDigestSent records that the matches were reported and restarts the digest period of their watches
+func (r *Repository) MarkDigestSent(ctx context.Context, watchIDs, matchIDs []int64, sentAt time.Time) error {
+	return r.execTx(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `UPDATE watch_matches SET notified_at = $1 WHERE match_id = ANY($2)`, sentAt, matchIDs); err != nil {
+			return fmt.Errorf("mark watch matches notified: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `UPDATE company_watches SET last_digest_at = $1 WHERE watch_id = ANY($2)`, sentAt, watchIDs); err != nil {
+			return fmt.Errorf("mark watch digest sent: %w", err)
+		}
+		return nil
+	})
+}
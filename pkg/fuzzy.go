@@ -0,0 +1,85 @@
+package pkg
+
+import (
+	"strings"
+	"unicode"
+)
+
+// fillerWords carry no meaning when comparing interview questions. Verbs like "design",
+// "implement" and "find" are kept, they tell apart questions about the same topic.
+var fillerWords = map[string]bool{
+	"a": true, "an": true, "the": true, "to": true, "of": true, "for": true,
+	"and": true, "in": true, "on": true, "with": true, "is": true, "are": true,
+	"how": true, "what": true, "would": true, "you": true, "your": true,
+	"write": true, "given": true, "problem": true, "question": true, "asked": true,
+	"me": true, "we": true, "i": true, "was": true, "that": true,
+}
+
+// NormalizeText lowercases text, strips punctuation and filler words so that
+// differently phrased copies of the same question compare equal. Letters of every
+// script are kept, text without any letters or digits normalizes to "".
+func NormalizeText(text string) string {
+	text = strings.Map(func(r rune) rune {
+		// marks are kept with their letters, Devanagari vowel signs are marks
+		if unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, strings.ToLower(text))
+
+	words := strings.Fields(text)
+	kept := make([]string, 0, len(words))
+	for _, w := range words {
+		if !fillerWords[w] {
+			kept = append(kept, w)
+		}
+	}
+
+	if len(kept) == 0 {
+		// everything was filler, fall back to the plain words
+		return strings.Join(words, " ")
+	}
+	return strings.Join(kept, " ")
+}
+
+// Similarity returns the Sørensen–Dice coefficient (0..1) over character
+// trigrams of the two normalized strings. An empty string matches nothing.
+func Similarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for g, n := range ta {
+		shared += min(n, tb[g])
+	}
+
+	total := 0
+	for _, n := range ta {
+		total += n
+	}
+	for _, n := range tb {
+		total += n
+	}
+
+	return 2 * float64(shared) / float64(total)
+}
+
+func trigrams(s string) map[string]int {
+	out := make(map[string]int)
+	for _, w := range strings.Fields(s) {
+		padded := []rune("  " + w + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			out[string(padded[i:i+3])]++
+		}
+	}
+	return out
+}
@@ -3,6 +3,7 @@ package pkg
 import (
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 func GenerateSlug(company string) string {
@@ -33,3 +34,14 @@ func CalculateGrowth(current, previous int) int {
 func StringPtr(s string) *string {
 	return &s
 }
+
+// Truncate cuts s to at most n bytes without splitting a multi-byte character
+func Truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
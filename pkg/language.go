@@ -0,0 +1,104 @@
+package pkg
+
+import (
+	"strings"
+	"unicode"
+)
+
+// LanguageUndetermined is returned when no language stands out, it is searched without stemming
+const LanguageUndetermined = "und"
+
+// SearchLanguages are the language codes accepted as a search preference. Codes without
+// a stemming configuration in search_config_for (see the migrations) are searched unstemmed.
+var SearchLanguages = map[string]bool{
+	"en": true, "es": true, "fr": true, "de": true, "pt": true,
+	"it": true, "nl": true, "ru": true, "hi": true, "simple": true,
+}
+
+// stopWordsByLanguage are frequent function words that identify a Latin-script language
+var stopWordsByLanguage = map[string][]string{
+	"en": {"the", "and", "was", "were", "with", "that", "this", "have", "for", "then", "asked", "they", "what", "which", "about"},
+	"es": {"el", "los", "las", "que", "una", "por", "con", "para", "del", "pero", "como", "fue", "muy", "tambien", "entrevista"},
+	"fr": {"le", "les", "des", "une", "est", "que", "pour", "pas", "avec", "dans", "mais", "sur", "nous", "entretien", "etait"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "mit", "ich", "ein", "eine", "auf", "wurde", "sie", "auch", "war"},
+	"pt": {"os", "que", "uma", "para", "com", "nao", "foi", "mas", "como", "muito", "entrevista", "sobre", "pela", "pelo", "tambem"},
+	"it": {"il", "che", "gli", "una", "per", "non", "con", "sono", "della", "anche", "come", "colloquio", "molto", "stato", "delle"},
+	"nl": {"de", "het", "een", "van", "en", "niet", "met", "ik", "voor", "zijn", "ook", "maar", "werd", "dat", "sollicitatie"},
+}
+
+// hinglishWords mark Hindi written in Latin script, common in GfG and Reddit posts
+var hinglishWords = map[string]bool{
+	"hai": true, "hain": true, "tha": true, "thi": true, "kya": true, "nahi": true,
+	"mein": true, "aur": true, "ki": true, "ke": true, "ko": true, "se": true, "bhi": true,
+	"kuch": true, "bahut": true, "mujhe": true, "maine": true, "wala": true, "karna": true,
+	"kiya": true, "gaya": true, "raha": true, "rahi": true, "hua": true, "yeh": true, "woh": true,
+}
+
+// DetectLanguage guesses the language of a text and returns its ISO 639-1 code, or
+// LanguageUndetermined. Hindi, whether in Devanagari or mixed into English, is reported
+// as "hi" since stemming for another language would break it.
+func DetectLanguage(text string) string {
+	var latin, devanagari, cyrillic, letters int
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Devanagari, r):
+			devanagari++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		}
+	}
+	if letters == 0 {
+		return LanguageUndetermined
+	}
+
+	switch {
+	case devanagari*5 >= letters:
+		return "hi"
+	case cyrillic*2 >= letters:
+		return "ru"
+	case latin*2 < letters:
+		return LanguageUndetermined
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) })
+	if len(words) == 0 {
+		return LanguageUndetermined
+	}
+
+	hinglish := 0
+	scores := map[string]int{}
+	for _, w := range words {
+		if hinglishWords[w] {
+			hinglish++
+		}
+		for lang, stop := range stopWordsByLanguage {
+			for _, s := range stop {
+				if w == s {
+					scores[lang]++
+					break
+				}
+			}
+		}
+	}
+
+	if hinglish*100 >= len(words)*3 && hinglish >= 3 {
+		return "hi"
+	}
+
+	best, bestScore := LanguageUndetermined, 0
+	for _, lang := range []string{"en", "es", "fr", "de", "pt", "it", "nl"} {
+		if scores[lang] > bestScore {
+			best, bestScore = lang, scores[lang]
+		}
+	}
+	if bestScore*100 < len(words)*5 {
+		return LanguageUndetermined
+	}
+	return best
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Attachment is a file an interview was created from
+type Attachment struct {
+	AttachmentID int64     `json:"attachment_id"`
+	InterviewID  int64     `json:"interview_id"`
+	Filename     string    `json:"filename"`
+	ContentType  string    `json:"content_type"`
+	Size         int       `json:"size"`
+	BlobKey      string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -46,3 +46,53 @@ type CompanyNameListReq struct {
 	Offset int     `json:"offset" form:"offset,default=0"`
 	Search *string `json:"search" form:"search"`
 }
+
+type BriefRound struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type BriefTopic struct {
+	Topic    string `json:"topic"`
+	Mentions int    `json:"mentions"`
+}
+
+type CompanyBriefContent struct {
+	Summary          string       `json:"summary"`
+	RoundStructure   []BriefRound `json:"round_structure"`
+	CommonTopics     []BriefTopic `json:"common_topics"`
+	DifficultyTrend  string       `json:"difficulty_trend"`
+	BehavioralThemes []string     `json:"behavioral_themes"`
+	Tips             []string     `json:"tips"`
+}
+
+type CompanyBrief struct {
+	CompanyID      uuid.UUID           `json:"company_id"`
+	CompanyName    string              `json:"company_name"`
+	Brief          CompanyBriefContent `json:"brief"`
+	InterviewCount int                 `json:"interview_count"`
+	GeneratedAt    time.Time           `json:"generated_at"`
+	// Stale is set when the interviews changed but the brief could not be regenerated
+	Stale       bool   `json:"stale"`
+	Fingerprint string `json:"-"`
+}
+
+type CompanyBriefQuery struct {
+	Refresh bool `form:"refresh"`
+}
+
+// BriefInterview is the part of an interview the company brief is generated from
+type BriefInterview struct {
+	InterviewID int64
+	CreatedAt   time.Time
+	Position    *string
+	NoOfRound   *int
+	Location    *string
+	RawInput    string
+	Questions   []BriefQuestion
+}
+
+type BriefQuestion struct {
+	Question string
+	Type     string
+}
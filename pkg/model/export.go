@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// ExportVersion is bumped whenever the JSON export layout changes in a way the import has to know about
+const ExportVersion = 1
+
+type ExportFormat string
+
+const (
+	ExportFormatJSON     ExportFormat = "json"
+	ExportFormatCSV      ExportFormat = "csv"
+	ExportFormatMarkdown ExportFormat = "markdown"
+)
+
+type ExportQuery struct {
+	Format ExportFormat `form:"format,default=json"`
+}
+
+// ExportArchive is the JSON export, it can be imported back as is
+type ExportArchive struct {
+	Version    int               `json:"version"`
+	ExportedAt time.Time         `json:"exported_at"`
+	Companies  []ExportCompany   `json:"companies"`
+	Interviews []ExportInterview `json:"interviews"`
+}
+
+type ExportCompany struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type ExportInterview struct {
+	InterviewID    int64                  `json:"interview_id"`
+	Company        string                 `json:"company"`
+	Source         Source                 `json:"source"`
+	Title          string                 `json:"title"`
+	Position       *string                `json:"position"`
+	NoOfRound      *int                   `json:"no_of_round"`
+	Location       *string                `json:"location"`
+	Language       string                 `json:"language"`
+	RawInput       string                 `json:"raw_input"`
+	FullExperience string                 `json:"full_experience"`
+	Metadata       map[string]interface{} `json:"metadata"`
+	Tags           []string               `json:"tags"`
+	CreatedAt      time.Time              `json:"created_at"`
+	Questions      []ExportQuestion       `json:"questions"`
+	Provenance
+}
+
+type ExportQuestion struct {
+	QID          int64    `json:"q_id"`
+	Question     string   `json:"question"`
+	Type         string   `json:"type"`
+	Tags         []string `json:"tags"`
+	LeetcodeSlug *string  `json:"leetcode_slug"`
+	Note         *string  `json:"note"`
+}
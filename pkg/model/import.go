@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImportJobStatus string
+
+const (
+	ImportJobQueued    ImportJobStatus = "queued"
+	ImportJobRunning   ImportJobStatus = "running"
+	ImportJobCompleted ImportJobStatus = "completed"
+	ImportJobFailed    ImportJobStatus = "failed"
+)
+
+type ImportFormat string
+
+const (
+	ImportFormatCSV  ImportFormat = "csv"
+	ImportFormatJSON ImportFormat = "json"
+)
+
+// ImportReq is the multipart form of an import, the file itself is sent as "file"
+type ImportReq struct {
+	Format ImportFormat `form:"format"`
+	// Mapping is a JSON object from import field to CSV column header, e.g. {"company": "Company Name"}
+	Mapping          string `form:"mapping"`
+	DryRun           bool   `form:"dry_run"`
+	ExtractQuestions bool   `form:"extract_questions"`
+}
+
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportJob struct {
+	JobID            int64            `json:"job_id"`
+	UserID           uuid.UUID        `json:"user_id"`
+	Format           ImportFormat     `json:"format"`
+	Filename         string           `json:"filename"`
+	Status           ImportJobStatus  `json:"status"`
+	DryRun           bool             `json:"dry_run"`
+	ExtractQuestions bool             `json:"extract_questions"`
+	TotalRows        int              `json:"total_rows"`
+	ProcessedRows    int              `json:"processed_rows"`
+	CreatedCount     int              `json:"created_count"`
+	ErrorCount       int              `json:"error_count"`
+	Errors           []ImportRowError `json:"errors"`
+	Error            *string          `json:"error"`
+	CreatedAt        time.Time        `json:"created_at"`
+	FinishedAt       *time.Time       `json:"finished_at"`
+}
+
+type ImportJobListQuery struct {
+	Limit  int `form:"limit,default=20"`
+	Offset int `form:"offset,default=0"`
+}
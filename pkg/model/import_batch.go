@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+type BatchItemStatus string
+
+const (
+	BatchItemQueued     BatchItemStatus = "queued"
+	BatchItemProcessing BatchItemStatus = "processing"
+	BatchItemCompleted  BatchItemStatus = "completed"
+	BatchItemFailed     BatchItemStatus = "failed"
+	// BatchItemDuplicate is a URL that was already imported, or listed twice in the batch
+	BatchItemDuplicate BatchItemStatus = "duplicate"
+)
+
+type BatchImportReq struct {
+	URLs []string `json:"urls" binding:"required,min=1,max=50,dive,required"`
+}
+
+type ImportBatchItem struct {
+	ItemID       int64           `json:"item_id"`
+	URL          string          `json:"url"`
+	CanonicalURL *string         `json:"canonical_url"`
+	Source       *Source         `json:"source"`
+	Status       BatchItemStatus `json:"status"`
+	InterviewID  *int64          `json:"interview_id"`
+	Error        *string         `json:"error"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+type ImportBatch struct {
+	BatchID    int64                   `json:"batch_id"`
+	Done       bool                    `json:"done"`
+	Total      int                     `json:"total"`
+	Counts     map[BatchItemStatus]int `json:"counts"`
+	Items      []ImportBatchItem       `json:"items"`
+	CreatedAt  time.Time               `json:"created_at"`
+	FinishedAt *time.Time              `json:"finished_at"`
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// IngestAddress is the address a user emails interview notes to
+type IngestAddress struct {
+	Address   string    `json:"address"`
+	Token     string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
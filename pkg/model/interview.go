@@ -21,6 +21,8 @@ type ProcessStatus string
 const (
 	ProcessStatusSuccess ProcessStatus = "success"
 	ProcessStatusFailed  ProcessStatus = "failed"
+	// queued interviews only hold the URL of a post found by a company watch until it is imported
+	ProcessStatusQueued ProcessStatus = "queued"
 )
 
 type Interview struct {
@@ -35,9 +37,20 @@ type Interview struct {
 	Position      *string                `json:"position" db:"position"`
 	NoOfRound     *int                   `json:"no_of_round" db:"no_of_round"`
 	Location      *string                `json:"location" db:"location"`
+	Language      string                 `json:"language" db:"language"`
 	Metadata      map[string]interface{} `json:"metadata" db:"metadata"`
 	CreatedAt     time.Time              `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time              `json:"updated_at" db:"updated_at"`
+	Provenance
+}
+
+// Provenance records where a fetched interview came from, it is empty for pasted text
+type Provenance struct {
+	SourceURL         *string    `json:"source_url" db:"source_url"`
+	CanonicalURL      *string    `json:"canonical_url" db:"canonical_url"`
+	FetchedAt         *time.Time `json:"fetched_at" db:"fetched_at"`
+	SourcePublishedAt *time.Time `json:"source_published_at" db:"source_published_at"`
+	ContentHash       *string    `json:"content_hash" db:"content_hash"`
 }
 
 type CreateInterviewWithAIReq struct {
@@ -58,6 +71,7 @@ type CreateInterviewReq struct {
 type Filter struct {
 	Source        *[]Source        `json:"source" form:"source"`
 	ProcessStatus *[]ProcessStatus `json:"process_status" form:"process_status"`
+	Tags          *[]int64         `json:"tags" form:"tags"`
 }
 
 type ListInterviewQuery struct {
@@ -123,9 +137,12 @@ type InterviewRes struct {
 	Position      *string                `json:"position"`
 	NoOfRound     *int                   `json:"no_of_round"`
 	Location      *string                `json:"location"`
+	Language      string                 `json:"language"`
 	CreatedAt     time.Time              `json:"created_at"`
 	Metadata      map[string]interface{} `json:"metadata"`
 	CompanyName   *string                `json:"company_name"`
+	Tags          []Tag                  `json:"tags"`
+	Provenance
 }
 
 type InterviewListItem struct {
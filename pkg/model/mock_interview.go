@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MockInterviewStatus string
+
+const (
+	MockInterviewInProgress MockInterviewStatus = "in_progress"
+	MockInterviewCompleted  MockInterviewStatus = "completed"
+)
+
+type CreateMockInterviewReq struct {
+	DSA          *int `json:"dsa" binding:"omitempty,min=0,max=10"`
+	SystemDesign *int `json:"system_design" binding:"omitempty,min=0,max=5"`
+	Behavioral   *int `json:"behavioral" binding:"omitempty,min=0,max=10"`
+}
+
+// MockPoolQuestion is a question the company asked before, with how often it came up
+type MockPoolQuestion struct {
+	QID        int64
+	Question   string
+	Type       string
+	TimesAsked int
+}
+
+type MockFeedback struct {
+	Score        int      `json:"score"`
+	Summary      string   `json:"summary"`
+	Strengths    []string `json:"strengths"`
+	Improvements []string `json:"improvements"`
+}
+
+type MockInterviewQuestion struct {
+	MQID       int64         `json:"mq_id"`
+	RoundNo    int           `json:"round_no"`
+	RoundName  string        `json:"round_name"`
+	Position   int           `json:"position"`
+	Question   string        `json:"question"`
+	Type       string        `json:"type"`
+	QID        *int64        `json:"q_id"`
+	TimesAsked int           `json:"times_asked"`
+	Answer     *string       `json:"answer"`
+	Feedback   *MockFeedback `json:"feedback"`
+	Score      *int          `json:"score"`
+	AnsweredAt *time.Time    `json:"answered_at"`
+}
+
+type MockInterview struct {
+	MockID      int64                   `json:"mock_id"`
+	UserID      uuid.UUID               `json:"user_id"`
+	CompanyID   uuid.UUID               `json:"company_id"`
+	CompanyName string                  `json:"company_name"`
+	Title       string                  `json:"title"`
+	Status      MockInterviewStatus     `json:"status"`
+	CreatedAt   time.Time               `json:"created_at"`
+	Questions   []MockInterviewQuestion `json:"questions"`
+}
+
+type MockInterviewListItem struct {
+	MockID         int64               `json:"mock_id"`
+	CompanyID      uuid.UUID           `json:"company_id"`
+	CompanyName    string              `json:"company_name"`
+	Title          string              `json:"title"`
+	Status         MockInterviewStatus `json:"status"`
+	TotalQuestions int                 `json:"total_questions"`
+	Answered       int                 `json:"answered"`
+	AvgScore       *float64            `json:"avg_score"`
+	CreatedAt      time.Time           `json:"created_at"`
+}
+
+type AnswerMockQuestionReq struct {
+	Answer string `json:"answer" binding:"required"`
+}
+
+type MockInterviewListQuery struct {
+	Limit  int `form:"limit,default=20"`
+	Offset int `form:"offset,default=0"`
+}
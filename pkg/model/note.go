@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+type CodeSnippet struct {
+	Language string `json:"language" binding:"required,max=30"`
+	Title    string `json:"title" binding:"max=200"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type QuestionNote struct {
+	QID       int64         `json:"q_id"`
+	Content   string        `json:"content"`
+	Snippets  []CodeSnippet `json:"snippets"`
+	Revision  int           `json:"revision"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+type NoteRevision struct {
+	Revision  int           `json:"revision"`
+	Content   string        `json:"content"`
+	Snippets  []CodeSnippet `json:"snippets"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type SaveNoteReq struct {
+	Content  string        `json:"content"`
+	Snippets []CodeSnippet `json:"snippets" binding:"max=20,dive"`
+	// BaseRevision is the revision the edit started from; a mismatch means someone else saved first
+	BaseRevision *int `json:"base_revision"`
+}
+
+type QuestionSearchQuery struct {
+	Q      string  `form:"q" binding:"required"`
+	Type   *string `form:"type"`
+	Limit  int     `form:"limit,default=20"`
+	Offset int     `form:"offset,default=0"`
+}
+
+type QuestionSearchResult struct {
+	QID         int64   `json:"q_id"`
+	InterviewID int64   `json:"interview_id"`
+	Question    string  `json:"question"`
+	Type        string  `json:"type"`
+	CompanyName string  `json:"company_name"`
+	Snippet     string  `json:"snippet"`
+	Rank        float32 `json:"rank"`
+}
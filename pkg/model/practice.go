@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProgressStatus string
+
+const (
+	ProgressStatusUnseen    ProgressStatus = "unseen"
+	ProgressStatusAttempted ProgressStatus = "attempted"
+	ProgressStatusSolved    ProgressStatus = "solved"
+	ProgressStatusReview    ProgressStatus = "review"
+)
+
+type QuestionProgress struct {
+	UserID         uuid.UUID      `json:"user_id" db:"user_id"`
+	QID            int64          `json:"q_id" db:"q_id"`
+	Status         ProgressStatus `json:"status" db:"status"`
+	Confidence     *int           `json:"confidence" db:"confidence"`
+	EaseFactor     float64        `json:"ease_factor" db:"ease_factor"`
+	IntervalDays   int            `json:"interval_days" db:"interval_days"`
+	Repetitions    int            `json:"repetitions" db:"repetitions"`
+	DueAt          *time.Time     `json:"due_at" db:"due_at"`
+	LastReviewedAt *time.Time     `json:"last_reviewed_at" db:"last_reviewed_at"`
+	ReviewCount    int            `json:"review_count" db:"review_count"`
+}
+
+type PracticeItem struct {
+	QID         int64             `json:"q_id"`
+	InterviewID int64             `json:"interview_id"`
+	Question    string            `json:"question"`
+	Type        string            `json:"type"`
+	CompanyName string            `json:"company_name"`
+	IsNew       bool              `json:"is_new"`
+	Progress    *QuestionProgress `json:"progress"`
+}
+
+type PracticeDueQuery struct {
+	Limit    int     `form:"limit,default=20"`
+	NewLimit int     `form:"new,default=5"`
+	Type     *string `form:"type"`
+}
+
+type ReviewQuestionReq struct {
+	Quality    *int `json:"quality" binding:"required,min=0,max=5"`
+	Confidence *int `json:"confidence" binding:"omitempty,min=1,max=5"`
+}
+
+type UpdateProgressReq struct {
+	Status     ProgressStatus `json:"status" binding:"required,oneof=unseen attempted solved review"`
+	Confidence *int           `json:"confidence" binding:"omitempty,min=1,max=5"`
+}
@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Question struct {
 	QID         int64     `json:"q_id" db:"q_id"`
@@ -11,10 +15,12 @@ type Question struct {
 }
 
 type QuestionRes struct {
-	QID         int64  `json:"q_id"`
-	InterviewID int64  `json:"interview_id"`
-	Question    string `json:"question"`
-	Type        string `json:"type"`
+	QID         int64         `json:"q_id"`
+	InterviewID int64         `json:"interview_id"`
+	Question    string        `json:"question"`
+	Type        string        `json:"type"`
+	CQID        *int64        `json:"cq_id"`
+	Leetcode    *LeetcodeLink `json:"leetcode"`
 }
 
 type ListQuestionsQuery struct {
@@ -32,3 +38,59 @@ type UpdateQuestionReq struct {
 	Question string `json:"question"`
 	Type     string `json:"type"`
 }
+
+type CanonicalQuestion struct {
+	CQID       int64     `json:"cq_id" db:"cq_id"`
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	Question   string    `json:"question" db:"question"`
+	Normalized string    `json:"-" db:"normalized"`
+	Type       string    `json:"type" db:"type"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+type QuestionBankItem struct {
+	CQID        int64     `json:"cq_id"`
+	Question    string    `json:"question"`
+	Type        string    `json:"type"`
+	Frequency   int       `json:"frequency"`
+	Companies   []string  `json:"companies"`
+	LastAskedAt time.Time `json:"last_asked_at"`
+}
+
+type QuestionBankQuery struct {
+	Type      *string `form:"type"`
+	CompanyID string  `form:"company_id"`
+	Limit     int     `form:"limit,default=20"`
+	Offset    int     `form:"offset,default=0"`
+}
+
+type LeetcodeLinkStatus string
+
+const (
+	LeetcodeLinkSuggested LeetcodeLinkStatus = "suggested"
+	LeetcodeLinkConfirmed LeetcodeLinkStatus = "confirmed"
+	LeetcodeLinkRejected  LeetcodeLinkStatus = "rejected"
+	LeetcodeLinkUnmatched LeetcodeLinkStatus = "unmatched"
+)
+
+type LeetcodeLink struct {
+	Slug       string             `json:"slug"`
+	Title      string             `json:"title"`
+	URL        string             `json:"url"`
+	Difficulty string             `json:"difficulty"`
+	Topics     []string           `json:"topics"`
+	Score      float32            `json:"score"`
+	Status     LeetcodeLinkStatus `json:"status"`
+}
+
+type SetLeetcodeLinkReq struct {
+	Slug string `json:"slug" binding:"required"`
+}
+
+type LeetcodeCandidate struct {
+	Slug       string   `json:"slug"`
+	Title      string   `json:"title"`
+	Difficulty string   `json:"difficulty"`
+	Topics     []string `json:"topics"`
+	Score      float64  `json:"score"`
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResyncQuestion is a question of an interview being resynced
+type ResyncQuestion struct {
+	QID      int64
+	Question string
+	Type     string
+}
+
+type ResyncRes struct {
+	InterviewID int64     `json:"interview_id"`
+	Changed     bool      `json:"changed"`
+	Added       int       `json:"added"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+// ResyncCandidate is an imported interview due for a scheduled resync
+type ResyncCandidate struct {
+	InterviewID int64
+	UserID      uuid.UUID
+}
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// ScrapeRule tells the generic fetcher how to read the posts of a site. Rules come from
+// the scrape_rules table or from the rules file.
+type ScrapeRule struct {
+	RuleID          int64     `json:"rule_id" yaml:"-"`
+	Name            string    `json:"name" yaml:"name" binding:"required,max=100"`
+	HostPattern     string    `json:"host_pattern" yaml:"host_pattern" binding:"required,max=255"` // example.com or *.example.com
+	PathPattern     string    `json:"path_pattern" yaml:"path_pattern"`                            // regular expression, empty matches every path
+	TitleSelector   string    `json:"title_selector" yaml:"title_selector"`
+	ContentSelector string    `json:"content_selector" yaml:"content_selector" binding:"required"`
+	DateSelector    string    `json:"date_selector" yaml:"date_selector"`
+	RemoveSelectors []string  `json:"remove_selectors" yaml:"remove_selectors"`
+	Enabled         bool      `json:"enabled" yaml:"-"`
+	CreatedAt       time.Time `json:"created_at" yaml:"-"`
+}
+
+// TestScrapeRuleReq runs a stored rule, or an unsaved one, against a URL
+type TestScrapeRuleReq struct {
+	URL    string      `json:"url" binding:"required,url"`
+	RuleID *int64      `json:"rule_id"`
+	Rule   *ScrapeRule `json:"rule"`
+}
+
+// ScrapePreview is what a rule extracted from a page
+type ScrapePreview struct {
+	Rule        string     `json:"rule"`
+	Matches     bool       `json:"matches"` // whether imports of the URL would use the rule
+	URL         string     `json:"url"`
+	Title       string     `json:"title"`
+	Content     string     `json:"content"`
+	PublishedAt *time.Time `json:"published_at"`
+	Length      int        `json:"length"`
+}
+
+// SaveScrapeRuleReq creates or replaces a rule, rules are enabled unless said otherwise
+type SaveScrapeRuleReq struct {
+	ScrapeRule
+	Enabled *bool `json:"enabled"`
+}
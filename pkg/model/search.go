@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SearchResultType string
+
+const (
+	SearchResultInterview SearchResultType = "interview"
+	SearchResultQuestion  SearchResultType = "question"
+	SearchResultCompany   SearchResultType = "company"
+)
+
+type SearchQuery struct {
+	Q      string              `form:"q" binding:"required"`
+	Types  *[]SearchResultType `form:"type"`
+	Limit  int                 `form:"limit,default=20"`
+	Offset int                 `form:"offset,default=0"`
+}
+
+type SearchResult struct {
+	Type        SearchResultType `json:"type"`
+	InterviewID *int64           `json:"interview_id,omitempty"`
+	QID         *int64           `json:"q_id,omitempty"`
+	CompanyID   uuid.UUID        `json:"company_id"`
+	CompanyName string           `json:"company_name"`
+	Title       string           `json:"title"`
+	Snippet     string           `json:"snippet"`
+	Rank        float32          `json:"rank"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+type SearchRes struct {
+	Results []SearchResult `json:"results"`
+	// Facets counts the matches per result type, regardless of the type filter
+	Facets map[SearchResultType]int `json:"facets"`
+}
+
+type SemanticSearchMode string
+
+const (
+	SemanticSearchModeSemantic SemanticSearchMode = "semantic"
+	SemanticSearchModeHybrid   SemanticSearchMode = "hybrid"
+)
+
+type SemanticSearchQuery struct {
+	Q      string              `form:"q" binding:"required"`
+	Mode   SemanticSearchMode  `form:"mode,default=hybrid"`
+	Types  *[]SearchResultType `form:"type"`
+	Limit  int                 `form:"limit,default=20"`
+	Offset int                 `form:"offset,default=0"`
+}
+
+// EmbeddingDoc is an interview or question whose embedding is missing or outdated
+type EmbeddingDoc struct {
+	Type        SearchResultType
+	ID          int64
+	Content     string
+	ContentHash string
+}
+
+// SemanticHit is an interview or question ranked by embedding similarity
+type SemanticHit struct {
+	Type       SearchResultType
+	ID         int64
+	Similarity float32
+}
+
+type SemanticSearchResult struct {
+	SearchResult
+	// Similarity is the cosine similarity to the query, nil when only the keyword search matched
+	Similarity *float32 `json:"similarity"`
+	// Score is the fused ranking score the results are ordered by
+	Score float64 `json:"score"`
+}
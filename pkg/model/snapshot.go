@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// InterviewSnapshot points to the archived raw response an interview was extracted from
+type InterviewSnapshot struct {
+	InterviewID int64     `json:"interview_id"`
+	BlobKey     string    `json:"-"`
+	ContentType string    `json:"content_type"`
+	Size        int       `json:"size"`
+	SourceURL   string    `json:"source_url"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
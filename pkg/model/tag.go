@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Tag struct {
+	TagID     int64     `json:"tag_id" db:"tag_id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Name      string    `json:"name" db:"name"`
+	Slug      string    `json:"slug" db:"slug"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type TagRes struct {
+	TagID          int64  `json:"tag_id"`
+	Name           string `json:"name"`
+	Slug           string `json:"slug"`
+	InterviewCount int    `json:"interview_count"`
+	QuestionCount  int    `json:"question_count"`
+}
+
+type CreateTagReq struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+type RenameTagReq struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+type MergeTagsReq struct {
+	SourceTagIDs []int64 `json:"source_tag_ids" binding:"required,min=1,max=100"`
+	TargetTagID  int64   `json:"target_tag_id" binding:"required"`
+}
+
+type SetTagsReq struct {
+	TagIDs []int64 `json:"tag_ids" binding:"max=50"`
+}
@@ -66,3 +66,11 @@ type ChangePasswordReq struct {
 	UserID      uuid.UUID `json:"user_id" binding:"required"`
 	NewPassword string    `json:"new_password" binding:"required,min=6"`
 }
+
+type UserPreferences struct {
+	SearchLanguage string `json:"search_language"`
+}
+
+type UpdatePreferencesReq struct {
+	SearchLanguage string `json:"search_language" binding:"required"`
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DigestPeriod string
+
+const (
+	DigestDaily  DigestPeriod = "daily"
+	DigestWeekly DigestPeriod = "weekly"
+	DigestOff    DigestPeriod = "off"
+)
+
+// WatchSources selects where a watch looks for new posts
+type WatchSources struct {
+	Leetcode   bool     `json:"leetcode"`
+	Subreddits []string `json:"subreddits"`
+	GfGPages   []string `json:"gfg_pages"` // GfG listing pages, such as company tag pages
+}
+
+// CompanyWatch subscribes a user to new interview posts about a company
+type CompanyWatch struct {
+	WatchID         int64        `json:"watch_id"`
+	UserID          uuid.UUID    `json:"-"`
+	Company         string       `json:"company"`
+	PositionKeyword *string      `json:"position_keyword"`
+	LeetcodeTag     string       `json:"leetcode_tag"`
+	Sources         WatchSources `json:"sources"`
+	Digest          DigestPeriod `json:"digest"`
+	LastPolledAt    *time.Time   `json:"last_polled_at"`
+	LastDigestAt    *time.Time   `json:"last_digest_at"`
+	CreatedAt       time.Time    `json:"created_at"`
+}
+
+type CreateWatchReq struct {
+	Company string `json:"company" binding:"required,max=100"`
+	// PositionKeyword only keeps posts whose title mentions it, such as "sde" or "frontend"
+	PositionKeyword string `json:"position_keyword" binding:"max=100"`
+	// LeetcodeTag defaults to the slug of the company name
+	LeetcodeTag string `json:"leetcode_tag" binding:"max=100"`
+	// Sources defaults to LeetCode only
+	Sources *WatchSources `json:"sources"`
+	Digest  DigestPeriod  `json:"digest" binding:"omitempty,oneof=daily weekly off"`
+}
+
+// WatchMatch is a post found by a watch
+type WatchMatch struct {
+	MatchID      int64        `json:"match_id"`
+	WatchID      int64        `json:"watch_id"`
+	UserID       uuid.UUID    `json:"-"`
+	Digest       DigestPeriod `json:"-"`
+	Company      string       `json:"company"`
+	Source       Source       `json:"source"`
+	URL          string       `json:"url"`
+	CanonicalURL string       `json:"canonical_url"`
+	Title        string       `json:"title"`
+	PostedAt     *time.Time   `json:"posted_at"`
+	BatchID      *int64       `json:"batch_id"`
+	InterviewID  *int64       `json:"interview_id"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
@@ -53,6 +53,14 @@ func Created(c *gin.Context, data interface{}) {
 	})
 }
 
+// Accepted sends a 202 response for work that continues in the background
+func Accepted(c *gin.Context, data interface{}) {
+	c.JSON(http.StatusAccepted, Envelope{
+		Success: true,
+		Data:    data,
+	})
+}
+
 // NoContent sends a 204 response with no body
 func NoContent(c *gin.Context) {
 	c.Status(http.StatusNoContent)